@@ -0,0 +1,82 @@
+package basics
+
+import (
+	"fmt"
+	"log"
+)
+
+// Database 演示函数式选项（functional options）模式的配置对象，
+// 类似 Java 里的 Builder 模式。
+type Database struct {
+	Host     string
+	Port     int
+	Name     string
+	Username string
+	Password string
+}
+
+// DatabaseOption 配置函数。
+type DatabaseOption func(*Database)
+
+func WithHost(host string) DatabaseOption {
+	return func(db *Database) { db.Host = host }
+}
+
+func WithPort(port int) DatabaseOption {
+	return func(db *Database) { db.Port = port }
+}
+
+func WithName(name string) DatabaseOption {
+	return func(db *Database) { db.Name = name }
+}
+
+func WithCredentials(username, password string) DatabaseOption {
+	return func(db *Database) { db.Username = username; db.Password = password }
+}
+
+// NewDatabaseWithOptions 原始版本：直接套用选项，不做任何校验。
+func NewDatabaseWithOptions(opts ...DatabaseOption) *Database {
+	db := &Database{Port: 3306}
+	for _, opt := range opts {
+		opt(db)
+	}
+	return db
+}
+
+// defaultDatabaseName 未显式指定库名时的默认值。
+const defaultDatabaseName = "app"
+
+// BuildDatabase 是带校验的构建入口：
+// host 必填，port 必须在 1-65535，name 缺省补默认值。
+func BuildDatabase(opts ...DatabaseOption) (*Database, error) {
+	db := NewDatabaseWithOptions(opts...)
+	if db.Host == "" {
+		return nil, fmt.Errorf("database: host is required")
+	}
+	if db.Port < 1 || db.Port > 65535 {
+		return nil, fmt.Errorf("database: port %d out of range 1-65535", db.Port)
+	}
+	if db.Name == "" {
+		db.Name = defaultDatabaseName
+	}
+	return db, nil
+}
+
+// Handler 是装饰器模式的处理函数签名，对应 Java 里的函数式接口。
+type Handler func(req string) (string, error)
+
+// WithRecover 给 handler 套一层 panic 兜底：
+// handler panic 时不向上传播，记一条日志并返回错误，
+// 可以和其他装饰器随意嵌套组合。
+func WithRecover(handler Handler) Handler {
+	return func(req string) (resp string, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("handler panic recovered: %v", r)
+				resp = ""
+				err = fmt.Errorf("handler panic: %v", r)
+			}
+		}()
+		return handler(req)
+	}
+}
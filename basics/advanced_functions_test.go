@@ -0,0 +1,88 @@
+package basics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildDatabaseValid(t *testing.T) {
+	db, err := BuildDatabase(WithHost("localhost"), WithPort(5432), WithName("study"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Host != "localhost" || db.Port != 5432 || db.Name != "study" {
+		t.Errorf("db = %+v", db)
+	}
+}
+
+func TestBuildDatabaseMissingHost(t *testing.T) {
+	if _, err := BuildDatabase(WithPort(3306)); err == nil || !strings.Contains(err.Error(), "host") {
+		t.Errorf("want host error, got %v", err)
+	}
+}
+
+func TestBuildDatabasePortOutOfRange(t *testing.T) {
+	for _, port := range []int{0, -1, 70000} {
+		if _, err := BuildDatabase(WithHost("h"), WithPort(port)); err == nil {
+			t.Errorf("port %d should be rejected", port)
+		}
+	}
+}
+
+func TestBuildDatabaseDefaultName(t *testing.T) {
+	db, err := BuildDatabase(WithHost("localhost"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Name != defaultDatabaseName {
+		t.Errorf("Name = %q, want default %q", db.Name, defaultDatabaseName)
+	}
+	if db.Port != 3306 {
+		t.Errorf("Port = %d, want default 3306", db.Port)
+	}
+}
+
+func TestWithRecoverCatchesPanic(t *testing.T) {
+	panicky := Handler(func(req string) (string, error) {
+		panic("boom: " + req)
+	})
+	resp, err := WithRecover(panicky)("x")
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("err = %v, want panic error", err)
+	}
+	if resp != "" {
+		t.Errorf("resp = %q, want fallback empty string", resp)
+	}
+}
+
+func TestWithRecoverPassthrough(t *testing.T) {
+	echo := Handler(func(req string) (string, error) {
+		return "echo:" + req, nil
+	})
+	resp, err := WithRecover(echo)("hi")
+	if err != nil || resp != "echo:hi" {
+		t.Errorf("resp = %q, err = %v", resp, err)
+	}
+}
+
+func TestWithRecoverComposes(t *testing.T) {
+	// 与其他装饰器嵌套：外层加前缀，里层兜 panic
+	upper := func(h Handler) Handler {
+		return func(req string) (string, error) {
+			resp, err := h(req)
+			return strings.ToUpper(resp), err
+		}
+	}
+	h := upper(WithRecover(func(req string) (string, error) {
+		if req == "bad" {
+			panic("bad input")
+		}
+		return req, nil
+	}))
+	if resp, err := h("ok"); err != nil || resp != "OK" {
+		t.Errorf("resp = %q, err = %v", resp, err)
+	}
+	if _, err := h("bad"); err == nil {
+		t.Error("composed handler should surface recovered error")
+	}
+}
@@ -0,0 +1,102 @@
+package basics
+
+import "sync"
+
+// Tee 把一个 channel 的数据广播给 n 个下游，每个下游都会收到全部元素，
+// 上游关闭后所有输出随之关闭。输出带默认缓冲，慢下游只有在自己的
+// 缓冲满了才会拖慢整体。
+func Tee[T any](in <-chan T, n int) []<-chan T {
+	return TeeBuffered(in, n, 16)
+}
+
+// TeeBuffered 可指定每个输出缓冲大小的版本。
+// buf 为 0 表示无缓冲（任何下游不取都会阻塞广播）。
+func TeeBuffered[T any](in <-chan T, n int, buf int) []<-chan T {
+	outs := make([]chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T, buf)
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for v := range in {
+			for _, out := range outs {
+				out <- v
+			}
+		}
+	}()
+
+	ros := make([]<-chan T, n)
+	for i, out := range outs {
+		ros[i] = out
+	}
+	return ros
+}
+
+// DropPolicy 决定 LossyChannel 满时丢谁。
+type DropPolicy int
+
+const (
+	DropOldest DropPolicy = iota // 挤掉最旧的，保证新数据进得来
+	DropNewest                   // 丢弃当前这条，保留已有数据
+)
+
+// LossyChannel 有损通道：Send 永不阻塞，缓冲满时按策略丢数据。
+// 适合监控指标这类“宁可丢也别卡住业务”的场景。
+type LossyChannel[T any] struct {
+	mu      sync.Mutex
+	ch      chan T
+	policy  DropPolicy
+	dropped int64
+}
+
+// NewLossyChannel 创建容量为 capacity 的有损通道，capacity 必须大于 0。
+func NewLossyChannel[T any](capacity int, policy DropPolicy) *LossyChannel[T] {
+	if capacity <= 0 {
+		panic("basics: lossy channel capacity must be positive")
+	}
+	return &LossyChannel[T]{ch: make(chan T, capacity), policy: policy}
+}
+
+// Send 投递一条数据，满时按策略丢弃并计数，永不阻塞。
+func (l *LossyChannel[T]) Send(v T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case l.ch <- v:
+		return
+	default:
+	}
+	l.dropped++
+	if l.policy == DropNewest {
+		return
+	}
+	// 挤掉最旧的再放新的；和 Recv 并发时旧值可能刚被取走，那就直接放
+	select {
+	case <-l.ch:
+	default:
+	}
+	l.ch <- v
+}
+
+// Recv 返回接收端，消费方用普通的 channel 语法读。
+func (l *LossyChannel[T]) Recv() <-chan T {
+	return l.ch
+}
+
+// Dropped 返回累计丢弃条数。
+func (l *LossyChannel[T]) Dropped() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dropped
+}
+
+// Close 关闭通道，之后不可再 Send。
+func (l *LossyChannel[T]) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	close(l.ch)
+}
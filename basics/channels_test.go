@@ -0,0 +1,134 @@
+package basics
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestTeeAllOutputsReceiveAll(t *testing.T) {
+	in := make(chan int)
+	outs := Tee(in, 3)
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var wg sync.WaitGroup
+	results := make([][]int, len(outs))
+	for i, out := range outs {
+		wg.Add(1)
+		go func(i int, out <-chan int) {
+			defer wg.Done()
+			for v := range out {
+				results[i] = append(results[i], v)
+			}
+		}(i, out)
+	}
+	wg.Wait()
+
+	want := []int{1, 2, 3, 4, 5}
+	for i, got := range results {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("output %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestTeeClosePropagates(t *testing.T) {
+	in := make(chan string)
+	outs := Tee(in, 2)
+	close(in)
+
+	for i, out := range outs {
+		if _, ok := <-out; ok {
+			t.Errorf("output %d should be closed", i)
+		}
+	}
+}
+
+func TestTeeBufferedSlowConsumer(t *testing.T) {
+	in := make(chan int)
+	outs := TeeBuffered(in, 2, 5)
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	// 只消费第一个输出，第二个靠缓冲兜着，广播不被卡死
+	var got []int
+	for v := range outs[0] {
+		got = append(got, v)
+	}
+	if len(got) != 5 {
+		t.Errorf("fast consumer got %v", got)
+	}
+	// 慢下游的数据仍然完整
+	var slow []int
+	for v := range outs[1] {
+		slow = append(slow, v)
+	}
+	if len(slow) != 5 {
+		t.Errorf("slow consumer got %v", slow)
+	}
+}
+
+func TestLossyChannelNotFull(t *testing.T) {
+	l := NewLossyChannel[int](3, DropOldest)
+	l.Send(1)
+	l.Send(2)
+	l.Close()
+	var got []int
+	for v := range l.Recv() {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("received %v", got)
+	}
+	if l.Dropped() != 0 {
+		t.Errorf("Dropped = %d, want 0", l.Dropped())
+	}
+}
+
+func TestLossyChannelDropOldest(t *testing.T) {
+	l := NewLossyChannel[int](2, DropOldest)
+	l.Send(1)
+	l.Send(2)
+	l.Send(3) // 挤掉 1
+	l.Close()
+	var got []int
+	for v := range l.Recv() {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("received %v, want [2 3]", got)
+	}
+	if l.Dropped() != 1 {
+		t.Errorf("Dropped = %d, want 1", l.Dropped())
+	}
+}
+
+func TestLossyChannelDropNewest(t *testing.T) {
+	l := NewLossyChannel[int](2, DropNewest)
+	l.Send(1)
+	l.Send(2)
+	l.Send(3) // 3 被丢弃
+	l.Send(4) // 4 也被丢弃
+	l.Close()
+	var got []int
+	for v := range l.Recv() {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("received %v, want [1 2]", got)
+	}
+	if l.Dropped() != 2 {
+		t.Errorf("Dropped = %d, want 2", l.Dropped())
+	}
+}
@@ -0,0 +1,82 @@
+package basics
+
+import "fmt"
+
+// As 安全类型断言的泛型封装，替代到处散落的 `v, ok := x.(T)`。
+// 断言失败时返回零值和 false，不会 panic。
+func As[T any](v any) (T, bool) {
+	t, ok := v.(T)
+	return t, ok
+}
+
+// MustAs 断言失败直接 panic 的版本，用在“类型一定对”的场合，
+// 类似 Java 的强制转换抛 ClassCastException。
+func MustAs[T any](v any) T {
+	t, ok := v.(T)
+	if !ok {
+		panic(fmt.Sprintf("type assertion failed: %T is not %T", v, t))
+	}
+	return t
+}
+
+// RuleContext 规则求值的上下文，键值随业务自定，比如 {"role": "admin"}。
+type RuleContext map[string]any
+
+// Rule 一条业务规则：Condition 为真时执行 Action。
+type Rule struct {
+	Name      string
+	Condition func(ctx RuleContext) bool
+	Action    func(ctx RuleContext)
+}
+
+// RuleEngine 把散落的 if-else 判断收拢成可注册的规则列表，
+// 按注册顺序逐条求值，匹配的都会执行（不短路）。
+type RuleEngine struct {
+	rules []Rule
+}
+
+// Register 追加一条规则。
+func (e *RuleEngine) Register(r Rule) {
+	e.rules = append(e.rules, r)
+}
+
+// Evaluate 按注册顺序执行所有条件满足的规则，返回命中的规则名。
+func (e *RuleEngine) Evaluate(ctx RuleContext) []string {
+	var fired []string
+	for _, r := range e.rules {
+		if r.Condition != nil && !r.Condition(ctx) {
+			continue
+		}
+		if r.Action != nil {
+			r.Action(ctx)
+		}
+		fired = append(fired, r.Name)
+	}
+	return fired
+}
+
+// NewPermissionEngine 用用户权限判断演示规则引擎的用法：
+// 根据 ctx 里的 role / owner 字段往 ctx["permissions"] 里累加权限。
+func NewPermissionEngine() *RuleEngine {
+	grant := func(ctx RuleContext, perm string) {
+		perms, _ := ctx["permissions"].([]string)
+		ctx["permissions"] = append(perms, perm)
+	}
+	e := &RuleEngine{}
+	e.Register(Rule{
+		Name:      "everyone-can-read",
+		Condition: func(RuleContext) bool { return true },
+		Action:    func(ctx RuleContext) { grant(ctx, "read") },
+	})
+	e.Register(Rule{
+		Name:      "owner-can-write",
+		Condition: func(ctx RuleContext) bool { owner, _ := ctx["owner"].(bool); return owner },
+		Action:    func(ctx RuleContext) { grant(ctx, "write") },
+	})
+	e.Register(Rule{
+		Name:      "admin-can-delete",
+		Condition: func(ctx RuleContext) bool { return ctx["role"] == "admin" },
+		Action:    func(ctx RuleContext) { grant(ctx, "delete") },
+	})
+	return e
+}
@@ -0,0 +1,93 @@
+package basics
+
+import "testing"
+
+func TestAsSuccess(t *testing.T) {
+	var v any = "hello"
+	s, ok := As[string](v)
+	if !ok || s != "hello" {
+		t.Errorf("As[string] = %q, %v", s, ok)
+	}
+}
+
+func TestAsFailure(t *testing.T) {
+	var v any = 42
+	s, ok := As[string](v)
+	if ok || s != "" {
+		t.Errorf("As[string](int) = %q, %v, want zero value and false", s, ok)
+	}
+}
+
+func TestAsPointer(t *testing.T) {
+	type point struct{ X int }
+	p := &point{X: 1}
+	var v any = p
+
+	got, ok := As[*point](v)
+	if !ok || got != p {
+		t.Errorf("As[*point] = %v, %v", got, ok)
+	}
+	if _, ok := As[*int](v); ok {
+		t.Error("As[*int] on *point should fail")
+	}
+}
+
+func TestMustAs(t *testing.T) {
+	var v any = 3.14
+	if got := MustAs[float64](v); got != 3.14 {
+		t.Errorf("MustAs = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustAs with wrong type should panic")
+		}
+	}()
+	_ = MustAs[int](v)
+}
+
+func TestRuleEngineOrderAndSkip(t *testing.T) {
+	var order []string
+	e := &RuleEngine{}
+	e.Register(Rule{
+		Name:      "first",
+		Condition: func(RuleContext) bool { return true },
+		Action:    func(RuleContext) { order = append(order, "first") },
+	})
+	e.Register(Rule{
+		Name:      "skipped",
+		Condition: func(RuleContext) bool { return false },
+		Action:    func(RuleContext) { order = append(order, "skipped") },
+	})
+	e.Register(Rule{
+		Name:      "second",
+		Condition: func(ctx RuleContext) bool { return ctx["go"] == true },
+		Action:    func(RuleContext) { order = append(order, "second") },
+	})
+
+	fired := e.Evaluate(RuleContext{"go": true})
+	if len(fired) != 2 || fired[0] != "first" || fired[1] != "second" {
+		t.Errorf("fired = %v", fired)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("actions ran = %v", order)
+	}
+}
+
+func TestPermissionEngine(t *testing.T) {
+	e := NewPermissionEngine()
+
+	ctx := RuleContext{"role": "admin", "owner": true}
+	e.Evaluate(ctx)
+	perms, _ := ctx["permissions"].([]string)
+	if len(perms) != 3 || perms[0] != "read" || perms[1] != "write" || perms[2] != "delete" {
+		t.Errorf("admin owner perms = %v", perms)
+	}
+
+	ctx = RuleContext{"role": "member"}
+	e.Evaluate(ctx)
+	perms, _ = ctx["permissions"].([]string)
+	if len(perms) != 1 || perms[0] != "read" {
+		t.Errorf("member perms = %v", perms)
+	}
+}
@@ -0,0 +1,100 @@
+package basics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 字节单位常量，1 KB = 1024 B。
+const (
+	KB int64 = 1 << (10 * (iota + 1))
+	MB
+	GB
+	TB
+)
+
+// HumanizeBytes 把字节数格式化成 "1.5 MB" 这样的可读字符串。
+// 小于 1 KB 直接显示整数字节，其余保留一位小数（结尾的 .0 去掉）。
+func HumanizeBytes(n int64) string {
+	switch {
+	case n < KB:
+		return fmt.Sprintf("%d B", n)
+	case n < MB:
+		return formatUnit(float64(n)/float64(KB), "KB")
+	case n < GB:
+		return formatUnit(float64(n)/float64(MB), "MB")
+	case n < TB:
+		return formatUnit(float64(n)/float64(GB), "GB")
+	default:
+		return formatUnit(float64(n)/float64(TB), "TB")
+	}
+}
+
+func formatUnit(v float64, unit string) string {
+	s := strconv.FormatFloat(v, 'f', 1, 64)
+	s = strings.TrimSuffix(s, ".0")
+	return s + " " + unit
+}
+
+// ParseBytes 解析 "1.5 MB"、"512KB"、"100" 这类字符串为字节数。
+// 不带单位按字节处理，单位大小写不敏感，空格可有可无。
+func ParseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("parse bytes: empty input")
+	}
+
+	units := []struct {
+		suffix string
+		factor int64
+	}{
+		{"TB", TB}, {"GB", GB}, {"MB", MB}, {"KB", KB}, {"B", 1},
+	}
+	upper := strings.ToUpper(s)
+	factor := int64(1)
+	num := s
+	for _, u := range units {
+		if strings.HasSuffix(upper, u.suffix) {
+			factor = u.factor
+			num = strings.TrimSpace(s[:len(s)-len(u.suffix)])
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse bytes %q: %w", s, err)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("parse bytes %q: negative size", s)
+	}
+	return int64(v * float64(factor)), nil
+}
+
+// ParseIntDefault 解析失败时退回默认值，省去调用处到处写 if err。
+func ParseIntDefault(s string, def int) int {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// ParseFloatDefault 同 ParseIntDefault，用于浮点数。
+func ParseFloatDefault(s string, def float64) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// MustParseInt 只用于“写死的字符串一定合法”的场景，失败直接 panic。
+func MustParseInt(s string) int {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		panic(fmt.Sprintf("must parse int %q: %v", s, err))
+	}
+	return v
+}
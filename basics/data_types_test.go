@@ -0,0 +1,100 @@
+package basics
+
+import "testing"
+
+func TestHumanizeBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1 KB"},
+		{1536, "1.5 KB"},
+		{3 * MB / 2, "1.5 MB"},
+		{2 * GB, "2 GB"},
+		{5 * TB, "5 TB"},
+	}
+	for _, tt := range tests {
+		if got := HumanizeBytes(tt.n); got != tt.want {
+			t.Errorf("HumanizeBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"100", 100},
+		{"512 B", 512},
+		{"1 KB", 1024},
+		{"1.5 MB", 3 * MB / 2},
+		{"2gb", 2 * GB},
+		{"512KB", 512 * KB},
+	}
+	for _, tt := range tests {
+		got, err := ParseBytes(tt.in)
+		if err != nil {
+			t.Errorf("ParseBytes(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseBytes(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseBytesInvalid(t *testing.T) {
+	for _, in := range []string{"", "abc", "MB", "-1 KB", "1.2.3 MB"} {
+		if _, err := ParseBytes(in); err == nil {
+			t.Errorf("ParseBytes(%q) should fail", in)
+		}
+	}
+}
+
+func TestHumanizeParseRoundTrip(t *testing.T) {
+	for _, n := range []int64{0, 1, 1024, 1536, 10 * MB, 3 * GB} {
+		got, err := ParseBytes(HumanizeBytes(n))
+		if err != nil {
+			t.Fatalf("round trip %d: %v", n, err)
+		}
+		if got != n {
+			t.Errorf("round trip %d -> %q -> %d", n, HumanizeBytes(n), got)
+		}
+	}
+}
+
+func TestParseIntDefault(t *testing.T) {
+	if got := ParseIntDefault("42", 7); got != 42 {
+		t.Errorf("ParseIntDefault(42) = %d", got)
+	}
+	if got := ParseIntDefault("oops", 7); got != 7 {
+		t.Errorf("ParseIntDefault(oops) = %d, want default 7", got)
+	}
+	if got := ParseIntDefault("", -1); got != -1 {
+		t.Errorf("ParseIntDefault(\"\") = %d, want -1", got)
+	}
+}
+
+func TestParseFloatDefault(t *testing.T) {
+	if got := ParseFloatDefault("3.14", 0); got != 3.14 {
+		t.Errorf("ParseFloatDefault(3.14) = %v", got)
+	}
+	if got := ParseFloatDefault("pi", 2.5); got != 2.5 {
+		t.Errorf("ParseFloatDefault(pi) = %v, want 2.5", got)
+	}
+}
+
+func TestMustParseIntPanics(t *testing.T) {
+	if got := MustParseInt("100"); got != 100 {
+		t.Errorf("MustParseInt(100) = %d", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseInt should panic on invalid input")
+		}
+	}()
+	MustParseInt("not a number")
+}
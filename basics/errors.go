@@ -0,0 +1,150 @@
+// Package basics 收录 Go 基础语法练习中沉淀的示例代码，
+// 每个文件围绕一个主题（错误处理、map、接口等）。
+package basics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// NetworkError 模拟网络请求失败时携带的上下文信息，
+// 对比 Java 里 IOException 的各种子类。
+type NetworkError struct {
+	Op      string        // 出错的操作，如 "GET"
+	URL     string        // 请求地址
+	Code    int           // HTTP 状态码，0 表示没有拿到响应
+	Timeout bool          // 是否超时
+	Hint    time.Duration // 服务端建议的重试间隔（如 Retry-After），可为 0
+	Err     error         // 底层错误
+}
+
+func (e *NetworkError) Error() string {
+	if e.Code > 0 {
+		return fmt.Sprintf("%s %s: status %d", e.Op, e.URL, e.Code)
+	}
+	return fmt.Sprintf("%s %s: %v", e.Op, e.URL, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// IsTimeout 判断是否为超时错误。
+func (e *NetworkError) IsTimeout() bool {
+	return e.Timeout
+}
+
+// IsServerError 判断是否为服务端错误（5xx）。
+func (e *NetworkError) IsServerError() bool {
+	return e.Code >= 500 && e.Code < 600
+}
+
+// IsRetryable 告诉调用方该不该重试：
+// 超时和 5xx 属于临时故障值得重试；429 是被限流，等一等也能重试；
+// 其余 4xx 是请求本身的问题，重试没有意义。
+func (e *NetworkError) IsRetryable() bool {
+	if e.IsTimeout() || e.IsServerError() {
+		return true
+	}
+	return e.Code == 429
+}
+
+// RetryAfter 给出建议的重试等待时间。
+// 优先采用服务端通过 Hint 给出的间隔；否则按错误类型给一个保守默认值；
+// 不可重试的错误返回 0。
+func (e *NetworkError) RetryAfter() time.Duration {
+	if !e.IsRetryable() {
+		return 0
+	}
+	if e.Hint > 0 {
+		return e.Hint
+	}
+	switch {
+	case e.Code == 429, e.Code == 503:
+		return 5 * time.Second
+	case e.IsServerError():
+		return 2 * time.Second
+	default: // 超时
+		return time.Second
+	}
+}
+
+// ErrorLevel 错误严重级别，数值越大越严重。
+type ErrorLevel int
+
+const (
+	LevelInfo ErrorLevel = iota
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+func (l ErrorLevel) String() string {
+	switch l {
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	case LevelFatal:
+		return "fatal"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// LeveledError 带严重级别的错误。
+type LeveledError struct {
+	Level ErrorLevel
+	Err   error
+}
+
+func (e *LeveledError) Error() string {
+	return fmt.Sprintf("[%s] %v", e.Level, e.Err)
+}
+
+func (e *LeveledError) Unwrap() error {
+	return e.Err
+}
+
+// levelOf 取出错误的级别；没标级别的按 error 算。
+func levelOf(err error) ErrorLevel {
+	var le *LeveledError
+	if errors.As(err, &le) {
+		return le.Level
+	}
+	return LevelError
+}
+
+// FilterByLevel 返回级别不低于 min 的那部分错误。
+func (m MultiError) FilterByLevel(min ErrorLevel) MultiError {
+	var out MultiError
+	for _, err := range m {
+		if levelOf(err) >= min {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// HasFatal 是否含 fatal 级错误。
+func (m MultiError) HasFatal() bool {
+	for _, err := range m {
+		if levelOf(err) == LevelFatal {
+			return true
+		}
+	}
+	return false
+}
+
+// SortByLevel 返回按级别从重到轻排序的副本，同级保持原有顺序。
+func (m MultiError) SortByLevel() MultiError {
+	out := append(MultiError{}, m...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return levelOf(out[i]) > levelOf(out[j])
+	})
+	return out
+}
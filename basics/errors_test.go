@@ -0,0 +1,100 @@
+package basics
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNetworkErrorIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  NetworkError
+		want bool
+	}{
+		{"timeout", NetworkError{Timeout: true}, true},
+		{"500", NetworkError{Code: 500}, true},
+		{"503", NetworkError{Code: 503}, true},
+		{"429 rate limited", NetworkError{Code: 429}, true},
+		{"400 bad request", NetworkError{Code: 400}, false},
+		{"404 not found", NetworkError{Code: 404}, false},
+		{"401 unauthorized", NetworkError{Code: 401}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.IsRetryable(); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkErrorRetryAfter(t *testing.T) {
+	if got := (&NetworkError{Code: 404}).RetryAfter(); got != 0 {
+		t.Errorf("non-retryable RetryAfter = %v, want 0", got)
+	}
+	if got := (&NetworkError{Code: 429, Hint: 30 * time.Second}).RetryAfter(); got != 30*time.Second {
+		t.Errorf("hinted RetryAfter = %v, want 30s", got)
+	}
+	if got := (&NetworkError{Code: 503}).RetryAfter(); got != 5*time.Second {
+		t.Errorf("503 RetryAfter = %v, want 5s", got)
+	}
+	if got := (&NetworkError{Code: 500}).RetryAfter(); got != 2*time.Second {
+		t.Errorf("500 RetryAfter = %v, want 2s", got)
+	}
+	if got := (&NetworkError{Timeout: true}).RetryAfter(); got != time.Second {
+		t.Errorf("timeout RetryAfter = %v, want 1s", got)
+	}
+}
+
+func leveled(l ErrorLevel, msg string) error {
+	return &LeveledError{Level: l, Err: errors.New(msg)}
+}
+
+func TestMultiErrorFilterByLevel(t *testing.T) {
+	m := MultiError{
+		leveled(LevelInfo, "i"),
+		leveled(LevelWarn, "w"),
+		leveled(LevelError, "e"),
+		leveled(LevelFatal, "f"),
+		errors.New("plain"), // 没标级别的按 error 算
+	}
+	got := m.FilterByLevel(LevelError)
+	if len(got) != 3 {
+		t.Fatalf("FilterByLevel(error) = %d errors, want 3", len(got))
+	}
+	if got := m.FilterByLevel(LevelFatal); len(got) != 1 {
+		t.Errorf("FilterByLevel(fatal) = %d errors, want 1", len(got))
+	}
+}
+
+func TestMultiErrorHasFatal(t *testing.T) {
+	m := MultiError{leveled(LevelWarn, "w")}
+	if m.HasFatal() {
+		t.Error("HasFatal = true without fatal errors")
+	}
+	m = append(m, leveled(LevelFatal, "boom"))
+	if !m.HasFatal() {
+		t.Error("HasFatal = false with a fatal error")
+	}
+}
+
+func TestMultiErrorSortByLevel(t *testing.T) {
+	m := MultiError{
+		leveled(LevelInfo, "i"),
+		leveled(LevelFatal, "f"),
+		leveled(LevelWarn, "w1"),
+		leveled(LevelWarn, "w2"),
+	}
+	sorted := m.SortByLevel()
+	want := []string{"[fatal] f", "[warn] w1", "[warn] w2", "[info] i"}
+	for i, w := range want {
+		if sorted[i].Error() != w {
+			t.Errorf("sorted[%d] = %q, want %q", i, sorted[i].Error(), w)
+		}
+	}
+	// 原切片不受影响
+	if m[0].Error() != "[info] i" {
+		t.Error("SortByLevel should not mutate the receiver")
+	}
+}
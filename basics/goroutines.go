@@ -0,0 +1,202 @@
+package basics
+
+import "sync"
+
+// DynamicPool 是可以运行时扩缩容的 worker pool。
+// 普通的固定 worker 池开多少个 goroutine 就定死了，
+// 这里每个 worker 带一个独立的停止信号，Resize 时增减。
+type DynamicPool struct {
+	tasks chan func()
+
+	mu      sync.Mutex
+	stops   []chan struct{} // 每个在跑的 worker 对应一个
+	stopped bool
+
+	wg sync.WaitGroup
+}
+
+// NewDynamicPool 启动 workers 个 worker，队列容量为 queueSize。
+func NewDynamicPool(workers, queueSize int) *DynamicPool {
+	p := &DynamicPool{tasks: make(chan func(), queueSize)}
+	p.Resize(workers)
+	return p
+}
+
+// Submit 提交任务，队列满时阻塞。池关闭后提交会 panic。
+func (p *DynamicPool) Submit(task func()) {
+	p.tasks <- task
+}
+
+// Workers 返回当前 worker 数。
+func (p *DynamicPool) Workers() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.stops)
+}
+
+// Backlog 返回队列积压的任务数，持续偏高说明该扩容了。
+func (p *DynamicPool) Backlog() int {
+	return len(p.tasks)
+}
+
+// Resize 把 worker 数调整为 n。
+// 缩容通过停止信号优雅退出多余 worker，队列里的任务不会丢，
+// 会由剩下的 worker 继续消费。
+func (p *DynamicPool) Resize(n int) {
+	if n < 0 {
+		n = 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	for len(p.stops) < n {
+		stop := make(chan struct{})
+		p.stops = append(p.stops, stop)
+		p.wg.Add(1)
+		go p.worker(stop)
+	}
+	for len(p.stops) > n {
+		last := len(p.stops) - 1
+		close(p.stops[last])
+		p.stops = p.stops[:last]
+	}
+}
+
+func (p *DynamicPool) worker(stop chan struct{}) {
+	defer p.wg.Done()
+	for {
+		// 优先响应停止信号，避免缩容时还抢任务
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		select {
+		case <-stop:
+			return
+		case task, ok := <-p.tasks:
+			if !ok {
+				return
+			}
+			task()
+		}
+	}
+}
+
+// Shutdown 停止接收新任务并等待队列清空、所有 worker 退出。
+func (p *DynamicPool) Shutdown() {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
+	p.mu.Unlock()
+
+	close(p.tasks)
+	p.wg.Wait()
+}
+
+// MultiError 聚合多个错误，Error 按收集顺序拼接。
+type MultiError []error
+
+func (m MultiError) Error() string {
+	if len(m) == 0 {
+		return ""
+	}
+	msg := m[0].Error()
+	for _, err := range m[1:] {
+		msg += "; " + err.Error()
+	}
+	return msg
+}
+
+// ErrorCollector 并发安全的错误收集器，
+// 配合 WaitGroup 用：每个 goroutine 出错就 Add，最后统一看 Err。
+type ErrorCollector struct {
+	mu   sync.Mutex
+	errs MultiError
+}
+
+// Add 记录一个错误，nil 会被忽略，可以无脑 Add。
+func (c *ErrorCollector) Add(err error) {
+	if err == nil {
+		return
+	}
+	c.mu.Lock()
+	c.errs = append(c.errs, err)
+	c.mu.Unlock()
+}
+
+// Err 没有错误时返回 nil，否则返回聚合后的 MultiError。
+func (c *ErrorCollector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+// RunLimited 并发处理 items，但同时运行的 goroutine 不超过 maxConcurrency：
+// 带缓冲 channel 当信号量，进场取令牌、出场还令牌。
+// 错误按发生顺序收集，全部成功返回 nil。
+func RunLimited[T any](items []T, maxConcurrency int, fn func(T) error) []error {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	sem := make(chan struct{}, maxConcurrency)
+	var collector ErrorCollector
+	var wg sync.WaitGroup
+	for _, item := range items {
+		item := item
+		wg.Add(1)
+		sem <- struct{}{} // 取令牌，满了就阻塞
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }() // 还令牌
+			collector.Add(fn(item))
+		}()
+	}
+	wg.Wait()
+
+	if errs := collector.Err(); errs != nil {
+		return errs.(MultiError)
+	}
+	return nil
+}
+
+// MapConcurrent fan-out 再 fan-in：workers 个 goroutine 并发处理 items，
+// 结果按原始下标放回，顺序与输入一致。workers 小于等于 0 按 1 处理。
+func MapConcurrent[T, R any](items []T, workers int, fn func(T) R) []R {
+	if len(items) == 0 {
+		return nil
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	out := make([]R, len(items))
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				out[i] = fn(items[i])
+			}
+		}()
+	}
+	for i := range items {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+	return out
+}
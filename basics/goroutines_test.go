@@ -0,0 +1,224 @@
+package basics
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDynamicPoolScaleUp(t *testing.T) {
+	p := NewDynamicPool(1, 10)
+	defer p.Shutdown()
+
+	// 两个任务互相等对方开始：1 个 worker 跑不完，扩容到 2 才能完成
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	done := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		p.Submit(func() {
+			barrier.Done()
+			barrier.Wait()
+		})
+	}
+	go func() {
+		barrier.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("two interlocking tasks should not finish with 1 worker")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	p.Resize(2)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("tasks should finish after scaling up to 2 workers")
+	}
+}
+
+func TestDynamicPoolScaleDownNoTaskLoss(t *testing.T) {
+	p := NewDynamicPool(4, 100)
+
+	var processed int64
+	const total = 50
+	for i := 0; i < total; i++ {
+		p.Submit(func() {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&processed, 1)
+		})
+	}
+	p.Resize(1)
+
+	deadline := time.After(5 * time.Second)
+	for atomic.LoadInt64(&processed) < total {
+		select {
+		case <-deadline:
+			t.Fatalf("processed %d of %d after scale down", processed, total)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	// 多余 worker 最终退出
+	for i := 0; i < 100 && p.Workers() != 1; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := p.Workers(); got != 1 {
+		t.Errorf("Workers() = %d, want 1", got)
+	}
+	p.Shutdown()
+}
+
+func TestDynamicPoolBacklog(t *testing.T) {
+	p := NewDynamicPool(0, 10)
+	p.Submit(func() {})
+	p.Submit(func() {})
+	if got := p.Backlog(); got != 2 {
+		t.Errorf("Backlog = %d, want 2", got)
+	}
+	p.Resize(1)
+	p.Shutdown()
+	if got := p.Backlog(); got != 0 {
+		t.Errorf("Backlog after shutdown = %d, want 0", got)
+	}
+}
+
+func TestErrorCollectorConcurrentAdd(t *testing.T) {
+	var c ErrorCollector
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				c.Add(fmt.Errorf("worker %d failed", i))
+			} else {
+				c.Add(nil) // nil 被忽略
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	err := c.Err()
+	if err == nil {
+		t.Fatal("want aggregated error")
+	}
+	if got := len(err.(MultiError)); got != 25 {
+		t.Errorf("collected %d errors, want 25", got)
+	}
+}
+
+func TestErrorCollectorNoErrors(t *testing.T) {
+	var c ErrorCollector
+	c.Add(nil)
+	if err := c.Err(); err != nil {
+		t.Errorf("Err = %v, want nil", err)
+	}
+}
+
+func TestMultiErrorMessage(t *testing.T) {
+	m := MultiError{errors.New("a"), errors.New("b")}
+	if got := m.Error(); got != "a; b" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestRunLimitedConcurrencyCap(t *testing.T) {
+	var current, peak atomic.Int64
+	items := make([]int, 30)
+	RunLimited(items, 4, func(int) error {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		current.Add(-1)
+		return nil
+	})
+	if got := peak.Load(); got > 4 {
+		t.Errorf("peak concurrency = %d, want <= 4", got)
+	}
+}
+
+func TestRunLimitedProcessesAllAndCollectsErrors(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	var processed atomic.Int64
+	errs := RunLimited(items, 2, func(i int) error {
+		processed.Add(1)
+		if i%2 == 0 {
+			return fmt.Errorf("item %d failed", i)
+		}
+		return nil
+	})
+	if processed.Load() != 5 {
+		t.Errorf("processed = %d, want 5", processed.Load())
+	}
+	if len(errs) != 2 {
+		t.Errorf("errs = %v, want 2", errs)
+	}
+}
+
+func TestRunLimitedEmpty(t *testing.T) {
+	if errs := RunLimited(nil, 3, func(int) error { return nil }); errs != nil {
+		t.Errorf("errs = %v, want nil", errs)
+	}
+}
+
+func TestMapConcurrentKeepsOrder(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	got := MapConcurrent(items, 8, func(v int) int {
+		time.Sleep(time.Duration(v%5) * time.Millisecond) // 打乱完成顺序
+		return v * v
+	})
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("got[%d] = %d, want %d", i, v, i*i)
+		}
+	}
+}
+
+func TestMapConcurrentConcurrencyCap(t *testing.T) {
+	var current, peak atomic.Int64
+	MapConcurrent(make([]int, 30), 4, func(int) int {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		current.Add(-1)
+		return 0
+	})
+	if got := peak.Load(); got > 4 {
+		t.Errorf("peak concurrency = %d, want <= 4", got)
+	}
+}
+
+func TestMapConcurrentEdgeCases(t *testing.T) {
+	if got := MapConcurrent(nil, 4, func(int) int { return 1 }); got != nil {
+		t.Errorf("empty input = %v, want nil", got)
+	}
+	// workers 多于元素数也能正常收尾
+	got := MapConcurrent([]int{1, 2}, 10, func(v int) int { return v + 1 })
+	if len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("got = %v", got)
+	}
+	// workers <= 0 退化为串行
+	got = MapConcurrent([]int{5}, 0, func(v int) int { return v })
+	if len(got) != 1 || got[0] != 5 {
+		t.Errorf("got = %v", got)
+	}
+}
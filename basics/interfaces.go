@@ -0,0 +1,108 @@
+package basics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Shape 几何图形接口，经典的接口入门示例。
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+// Circle 圆形。
+type Circle struct {
+	Radius float64
+}
+
+func (c Circle) Area() float64      { return math.Pi * c.Radius * c.Radius }
+func (c Circle) Perimeter() float64 { return 2 * math.Pi * c.Radius }
+
+// Rectangle 矩形。
+type Rectangle struct {
+	Width, Height float64
+}
+
+func (r Rectangle) Area() float64      { return r.Width * r.Height }
+func (r Rectangle) Perimeter() float64 { return 2 * (r.Width + r.Height) }
+
+// ShapeContainer 图形容器，演示用接口持有不同具体类型。
+type ShapeContainer struct {
+	shapes []Shape
+}
+
+func (sc *ShapeContainer) Add(s Shape) {
+	sc.shapes = append(sc.shapes, s)
+}
+
+func (sc *ShapeContainer) TotalArea() float64 {
+	total := 0.0
+	for _, s := range sc.shapes {
+		total += s.Area()
+	}
+	return total
+}
+
+func (sc *ShapeContainer) Shapes() []Shape {
+	return sc.shapes
+}
+
+// SortByArea 按面积升序原地排序。
+func (sc *ShapeContainer) SortByArea() {
+	sort.SliceStable(sc.shapes, func(i, j int) bool {
+		return sc.shapes[i].Area() < sc.shapes[j].Area()
+	})
+}
+
+// FilterShapesByType 用类型断言过滤出容器里某个具体类型的图形。
+// Go 的方法不能带类型参数，所以做成包级泛型函数。
+func FilterShapesByType[T Shape](sc *ShapeContainer) []T {
+	var out []T
+	for _, s := range sc.shapes {
+		if t, ok := s.(T); ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Plugin 插件接口：按名字注册、按名字执行，演示插件式架构。
+type Plugin interface {
+	Name() string
+	Execute(input string) (string, error)
+}
+
+// Registry 插件注册表，重名注册和运行未注册插件都报错。
+type Registry struct {
+	mu      sync.Mutex
+	plugins map[string]Plugin
+}
+
+func NewRegistry() *Registry {
+	return &Registry{plugins: make(map[string]Plugin)}
+}
+
+func (r *Registry) Register(p Plugin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := p.Name()
+	if _, ok := r.plugins[name]; ok {
+		return fmt.Errorf("plugin %q already registered", name)
+	}
+	r.plugins[name] = p
+	return nil
+}
+
+// Run 按名字执行插件。
+func (r *Registry) Run(name, input string) (string, error) {
+	r.mu.Lock()
+	p, ok := r.plugins[name]
+	r.mu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("plugin %q not registered", name)
+	}
+	return p.Execute(input)
+}
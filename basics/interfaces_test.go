@@ -0,0 +1,82 @@
+package basics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterShapesByType(t *testing.T) {
+	var sc ShapeContainer
+	sc.Add(Circle{Radius: 1})
+	sc.Add(Rectangle{Width: 2, Height: 3})
+	sc.Add(Circle{Radius: 2})
+
+	circles := FilterShapesByType[Circle](&sc)
+	if len(circles) != 2 || circles[0].Radius != 1 || circles[1].Radius != 2 {
+		t.Errorf("circles = %v", circles)
+	}
+	rects := FilterShapesByType[Rectangle](&sc)
+	if len(rects) != 1 {
+		t.Errorf("rects = %v", rects)
+	}
+}
+
+func TestSortByArea(t *testing.T) {
+	var sc ShapeContainer
+	sc.Add(Rectangle{Width: 10, Height: 10}) // 100
+	sc.Add(Circle{Radius: 1})                // ~3.14
+	sc.Add(Rectangle{Width: 2, Height: 5})   // 10
+
+	sc.SortByArea()
+	shapes := sc.Shapes()
+	for i := 1; i < len(shapes); i++ {
+		if shapes[i-1].Area() > shapes[i].Area() {
+			t.Errorf("not sorted at %d: %v > %v", i, shapes[i-1].Area(), shapes[i].Area())
+		}
+	}
+}
+
+func TestEmptyContainer(t *testing.T) {
+	var sc ShapeContainer
+	if got := FilterShapesByType[Circle](&sc); len(got) != 0 {
+		t.Errorf("filter on empty = %v", got)
+	}
+	sc.SortByArea() // 不应 panic
+	if got := sc.TotalArea(); got != 0 {
+		t.Errorf("TotalArea = %v", got)
+	}
+}
+
+// upperPlugin 测试用插件：输入转大写。
+type upperPlugin struct{}
+
+func (upperPlugin) Name() string { return "upper" }
+func (upperPlugin) Execute(input string) (string, error) {
+	return strings.ToUpper(input), nil
+}
+
+func TestRegistryRegisterAndRun(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register(upperPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.Run("upper", "hello")
+	if err != nil || out != "HELLO" {
+		t.Errorf("Run = %q, %v", out, err)
+	}
+}
+
+func TestRegistryDuplicateName(t *testing.T) {
+	r := NewRegistry()
+	r.Register(upperPlugin{})
+	if err := r.Register(upperPlugin{}); err == nil {
+		t.Error("duplicate registration should fail")
+	}
+}
+
+func TestRegistryUnknownPlugin(t *testing.T) {
+	r := NewRegistry()
+	if _, err := r.Run("nope", "x"); err == nil {
+		t.Error("running unregistered plugin should fail")
+	}
+}
@@ -0,0 +1,43 @@
+package basics
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// ProcessLines 逐行读取 r，用 workers 个 goroutine 并发处理每一行，
+// 返回所有处理错误。读取是流式的，整个文件不会一次进内存，
+// 适合大文件的 CSV 导入这类场景。
+func ProcessLines(r io.Reader, workers int, fn func(line string) error) []error {
+	if workers < 1 {
+		workers = 1
+	}
+	lines := make(chan string)
+	var collector ErrorCollector
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for line := range lines {
+				collector.Add(fn(line))
+			}
+		}()
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines <- scanner.Text()
+	}
+	close(lines)
+	wg.Wait()
+
+	if err := scanner.Err(); err != nil {
+		collector.Add(err)
+	}
+	if errs := collector.Err(); errs != nil {
+		return errs.(MultiError)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package basics
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProcessLinesAll(t *testing.T) {
+	input := "a\nb\nc\nd"
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	errs := ProcessLines(strings.NewReader(input), 3, func(line string) error {
+		mu.Lock()
+		seen[line] = true
+		mu.Unlock()
+		return nil
+	})
+	if errs != nil {
+		t.Fatalf("errs = %v", errs)
+	}
+	if len(seen) != 4 {
+		t.Errorf("processed %d lines, want 4: %v", len(seen), seen)
+	}
+}
+
+func TestProcessLinesCollectsErrors(t *testing.T) {
+	input := "ok\nbad\nok\nbad"
+	errs := ProcessLines(strings.NewReader(input), 2, func(line string) error {
+		if line == "bad" {
+			return fmt.Errorf("line %q failed", line)
+		}
+		return nil
+	})
+	if len(errs) != 2 {
+		t.Errorf("errs = %v, want 2", errs)
+	}
+}
+
+func TestProcessLinesEmptyInput(t *testing.T) {
+	errs := ProcessLines(strings.NewReader(""), 2, func(string) error {
+		t.Error("fn should not be called for empty input")
+		return nil
+	})
+	if errs != nil {
+		t.Errorf("errs = %v, want nil", errs)
+	}
+}
@@ -0,0 +1,32 @@
+package basics
+
+// 三种循环求和写法，抽成独立函数方便 go test -bench 对比。
+// 现代编译器下三者生成的代码几乎一样，benchmark 可以验证这一点。
+
+// SumIndexLen 每次循环都取 len(s)。
+func SumIndexLen(s []int) int {
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		sum += s[i]
+	}
+	return sum
+}
+
+// SumPrecomputed 先把长度存到局部变量。
+func SumPrecomputed(s []int) int {
+	sum := 0
+	n := len(s)
+	for i := 0; i < n; i++ {
+		sum += s[i]
+	}
+	return sum
+}
+
+// SumRange range 写法，最地道。
+func SumRange(s []int) int {
+	sum := 0
+	for _, v := range s {
+		sum += v
+	}
+	return sum
+}
@@ -0,0 +1,59 @@
+package basics
+
+import "testing"
+
+var sumFuncs = map[string]func([]int) int{
+	"SumIndexLen":    SumIndexLen,
+	"SumPrecomputed": SumPrecomputed,
+	"SumRange":       SumRange,
+}
+
+func TestSumFunctionsAgree(t *testing.T) {
+	cases := [][]int{
+		nil,
+		{},
+		{42},
+		{1, 2, 3, 4, 5},
+		{-1, 1, -2, 2},
+	}
+	wants := []int{0, 0, 42, 15, 0}
+	for i, s := range cases {
+		for name, f := range sumFuncs {
+			if got := f(s); got != wants[i] {
+				t.Errorf("%s(%v) = %d, want %d", name, s, got, wants[i])
+			}
+		}
+	}
+}
+
+func benchSlice() []int {
+	s := make([]int, 10000)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func BenchmarkSumIndexLen(b *testing.B) {
+	s := benchSlice()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumIndexLen(s)
+	}
+}
+
+func BenchmarkSumPrecomputed(b *testing.B) {
+	s := benchSlice()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumPrecomputed(s)
+	}
+}
+
+func BenchmarkSumRange(b *testing.B) {
+	s := benchSlice()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumRange(s)
+	}
+}
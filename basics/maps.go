@@ -0,0 +1,85 @@
+package basics
+
+// Set 用 map 实现的泛型集合，value 用空结构体不占内存，
+// 类似 Java 的 HashSet。
+type Set[T comparable] map[T]struct{}
+
+// NewSet 从元素列表构造集合。
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	for _, it := range items {
+		s.Add(it)
+	}
+	return s
+}
+
+func (s Set[T]) Add(item T)           { s[item] = struct{}{} }
+func (s Set[T]) Remove(item T)        { delete(s, item) }
+func (s Set[T]) Contains(item T) bool { _, ok := s[item]; return ok }
+func (s Set[T]) Len() int             { return len(s) }
+
+// Union 并集。
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	out := make(Set[T], len(s)+len(other))
+	for it := range s {
+		out.Add(it)
+	}
+	for it := range other {
+		out.Add(it)
+	}
+	return out
+}
+
+// Intersection 交集。
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	out := make(Set[T])
+	for it := range s {
+		if other.Contains(it) {
+			out.Add(it)
+		}
+	}
+	return out
+}
+
+// Difference 差集：在 s 中但不在 other 中的元素。
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	out := make(Set[T])
+	for it := range s {
+		if !other.Contains(it) {
+			out.Add(it)
+		}
+	}
+	return out
+}
+
+// SymmetricDifference 对称差：只出现在其中一个集合里的元素。
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	out := s.Difference(other)
+	for it := range other.Difference(s) {
+		out.Add(it)
+	}
+	return out
+}
+
+// IsSubsetOf 判断 s 的所有元素是否都在 other 中。空集是任何集合的子集。
+func (s Set[T]) IsSubsetOf(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for it := range s {
+		if !other.Contains(it) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf 判断 s 是否包含 other 的所有元素。
+func (s Set[T]) IsSupersetOf(other Set[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// Equal 判断两个集合元素完全相同。
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubsetOf(other)
+}
@@ -0,0 +1,62 @@
+package basics
+
+import "testing"
+
+func TestSetSymmetricDifference(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(3, 4, 5)
+	got := a.SymmetricDifference(b)
+	want := NewSet(1, 2, 4, 5)
+	if !got.Equal(want) {
+		t.Errorf("SymmetricDifference = %v, want %v", got, want)
+	}
+
+	if got := a.SymmetricDifference(a); got.Len() != 0 {
+		t.Errorf("A △ A = %v, want empty", got)
+	}
+}
+
+func TestSetSubsetSuperset(t *testing.T) {
+	small := NewSet(1, 2)
+	big := NewSet(1, 2, 3)
+
+	if !small.IsSubsetOf(big) {
+		t.Error("small should be subset of big")
+	}
+	if big.IsSubsetOf(small) {
+		t.Error("big should not be subset of small")
+	}
+	if !big.IsSupersetOf(small) {
+		t.Error("big should be superset of small")
+	}
+	if !NewSet(1, 2).IsSubsetOf(NewSet(1, 2)) {
+		t.Error("a set is a subset of itself")
+	}
+}
+
+func TestSetEmptyEdgeCases(t *testing.T) {
+	empty := NewSet[int]()
+	some := NewSet(1)
+
+	if !empty.IsSubsetOf(some) || !empty.IsSubsetOf(empty) {
+		t.Error("empty set is a subset of everything")
+	}
+	if !some.IsSupersetOf(empty) {
+		t.Error("any set is a superset of the empty set")
+	}
+	if got := empty.SymmetricDifference(some); !got.Equal(some) {
+		t.Errorf("∅ △ A = %v, want %v", got, some)
+	}
+}
+
+func TestSetEqual(t *testing.T) {
+	if !NewSet(1, 2, 3).Equal(NewSet(3, 2, 1)) {
+		t.Error("order should not matter")
+	}
+	if NewSet(1, 2).Equal(NewSet(1, 2, 3)) {
+		t.Error("different sizes should not be equal")
+	}
+	if !NewSet[int]().Equal(NewSet[int]()) {
+		t.Error("empty sets should be equal")
+	}
+}
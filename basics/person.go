@@ -0,0 +1,57 @@
+package basics
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Person 演示封装：age 是私有字段，只能通过 SetAge 校验后修改，
+// 类似 Java 里的 private 字段 + getter/setter。
+type Person struct {
+	Name string `json:"name"`
+	age  int
+
+	// internalID 纯内部标识，序列化时不输出。
+	internalID string
+}
+
+func NewPerson(name string, age int) (*Person, error) {
+	p := &Person{Name: name}
+	if err := p.SetAge(age); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Person) Age() int { return p.age }
+
+// SetAge 是唯一的年龄修改入口，范围 0-150。
+func (p *Person) SetAge(age int) error {
+	if age < 0 || age > 150 {
+		return fmt.Errorf("person: age %d out of range 0-150", age)
+	}
+	p.age = age
+	return nil
+}
+
+// MarshalJSON 把私有的 age 作为只读字段输出，internalID 继续隐藏。
+// 不定义这个方法的话，encoding/json 看不到未导出字段，输出会缺 age。
+func (p *Person) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}{Name: p.Name, Age: p.age})
+}
+
+// UnmarshalJSON 只读回 name，输入里的 age 被忽略：
+// age 是只读输出，想改必须走 SetAge 校验。
+func (p *Person) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	p.Name = in.Name
+	return nil
+}
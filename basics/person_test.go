@@ -0,0 +1,53 @@
+package basics
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPersonMarshalIncludesAge(t *testing.T) {
+	p, err := NewPerson("alice", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.internalID = "row-42"
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if !strings.Contains(got, `"age":30`) || !strings.Contains(got, `"name":"alice"`) {
+		t.Errorf("json = %s", got)
+	}
+	if strings.Contains(got, "row-42") {
+		t.Errorf("internalID leaked: %s", got)
+	}
+}
+
+func TestPersonUnmarshalAgeReadOnly(t *testing.T) {
+	p, _ := NewPerson("alice", 30)
+	if err := json.Unmarshal([]byte(`{"name":"bob","age":99}`), p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "bob" {
+		t.Errorf("Name = %q, want bob", p.Name)
+	}
+	// 输入里的 age 被忽略，仍是原值
+	if p.Age() != 30 {
+		t.Errorf("Age = %d, want 30 (read-only)", p.Age())
+	}
+}
+
+func TestPersonSetAgeValidates(t *testing.T) {
+	p, _ := NewPerson("alice", 30)
+	for _, age := range []int{-1, 151} {
+		if err := p.SetAge(age); err == nil {
+			t.Errorf("SetAge(%d) should fail", age)
+		}
+	}
+	if err := p.SetAge(65); err != nil || p.Age() != 65 {
+		t.Errorf("SetAge(65): err = %v, age = %d", err, p.Age())
+	}
+}
@@ -0,0 +1,58 @@
+package basics
+
+import "reflect"
+
+// redactedPlaceholder 敏感字段统一替换成的占位文本。
+const redactedPlaceholder = "***"
+
+// Redact 返回 v 的一个“可安全打印”副本：
+// 所有带 `sensitive:"true"` tag 的字符串字段会被替换为 ***。
+// 支持结构体、结构体指针以及嵌套结构体，其他类型原样返回。
+// 打日志时用 Redact(user) 代替 user，就不会把密码打出去了。
+func Redact(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return v
+	}
+	out := redactValue(rv)
+	return out.Interface()
+}
+
+// redactValue 递归处理，返回处理后的新值，不修改原对象。
+func redactValue(rv reflect.Value) reflect.Value {
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.New(rv.Type().Elem())
+		out.Elem().Set(redactValue(rv.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			if !field.IsExported() {
+				continue // 反射改不了未导出字段，跳过
+			}
+			fv := rv.Field(i)
+			if field.Tag.Get("sensitive") == "true" && fv.Kind() == reflect.String {
+				out.Field(i).SetString(redactedPlaceholder)
+				continue
+			}
+			out.Field(i).Set(redactValue(fv))
+		}
+		return out
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(redactValue(rv.Index(i)))
+		}
+		return out
+	default:
+		return rv
+	}
+}
@@ -0,0 +1,59 @@
+package basics
+
+import "testing"
+
+type redactAccount struct {
+	Username string
+	Password string `sensitive:"true"`
+	APIKey   string `sensitive:"true"`
+	Profile  redactProfile
+}
+
+type redactProfile struct {
+	Nickname string
+	Phone    string `sensitive:"true"`
+}
+
+func TestRedactMasksSensitiveFields(t *testing.T) {
+	in := redactAccount{Username: "alice", Password: "secret123", APIKey: "ak-1"}
+	got := Redact(in).(redactAccount)
+
+	if got.Password != "***" || got.APIKey != "***" {
+		t.Errorf("sensitive fields not masked: %+v", got)
+	}
+	if got.Username != "alice" {
+		t.Errorf("Username = %q, want alice", got.Username)
+	}
+	// 原对象不受影响
+	if in.Password != "secret123" {
+		t.Errorf("original modified: %+v", in)
+	}
+}
+
+func TestRedactNestedStruct(t *testing.T) {
+	in := &redactAccount{
+		Username: "bob",
+		Password: "pw",
+		Profile:  redactProfile{Nickname: "B", Phone: "13800138000"},
+	}
+	got := Redact(in).(*redactAccount)
+
+	if got.Profile.Phone != "***" {
+		t.Errorf("nested Phone = %q, want ***", got.Profile.Phone)
+	}
+	if got.Profile.Nickname != "B" {
+		t.Errorf("nested Nickname = %q, want B", got.Profile.Nickname)
+	}
+	if in.Profile.Phone != "13800138000" {
+		t.Errorf("original nested modified: %+v", in.Profile)
+	}
+}
+
+func TestRedactNonStructPassthrough(t *testing.T) {
+	if got := Redact(42); got != 42 {
+		t.Errorf("Redact(42) = %v", got)
+	}
+	if got := Redact("hello"); got != "hello" {
+		t.Errorf("Redact(hello) = %v", got)
+	}
+}
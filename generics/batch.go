@@ -0,0 +1,97 @@
+package generics
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchProcessor 攒批处理器：累积到 batchSize 或到 flushInterval
+// 就调用处理函数，典型用法是攒够一批再写数据库。
+// ctx 取消或 Close 时停止定时器并把剩余数据刷掉。
+type BatchProcessor[T any] struct {
+	mu      sync.Mutex
+	buf     []T
+	size    int
+	handler func([]T)
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	closed bool
+}
+
+// NewBatchProcessor 启动后台定时刷新 goroutine。
+// handler 在调用方（Add/Flush/Close）或定时器的 goroutine 里同步执行。
+func NewBatchProcessor[T any](ctx context.Context, batchSize int, flushInterval time.Duration, handler func([]T)) *BatchProcessor[T] {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	p := &BatchProcessor[T]{
+		size:    batchSize,
+		handler: handler,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go p.loop(ctx, flushInterval)
+	return p
+}
+
+func (p *BatchProcessor[T]) loop(ctx context.Context, interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Flush()
+		}
+	}
+}
+
+// Add 追加一个元素，攒满一批立即处理。
+func (p *BatchProcessor[T]) Add(v T) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.buf = append(p.buf, v)
+	var batch []T
+	if len(p.buf) >= p.size {
+		batch = p.buf
+		p.buf = nil
+	}
+	p.mu.Unlock()
+	if batch != nil {
+		p.handler(batch)
+	}
+}
+
+// Flush 立即处理当前积累的数据（可能不满一批）。
+func (p *BatchProcessor[T]) Flush() {
+	p.mu.Lock()
+	batch := p.buf
+	p.buf = nil
+	p.mu.Unlock()
+	if len(batch) > 0 {
+		p.handler(batch)
+	}
+}
+
+// Close 停止定时器并刷掉剩余数据，之后的 Add 会被忽略。
+func (p *BatchProcessor[T]) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.cancel()
+	<-p.done
+	p.Flush()
+}
@@ -0,0 +1,98 @@
+package generics
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *batchRecorder) handle(batch []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, batch)
+}
+
+func (r *batchRecorder) snapshot() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]int{}, r.batches...)
+}
+
+func TestBatchProcessorFullBatch(t *testing.T) {
+	var rec batchRecorder
+	p := NewBatchProcessor(context.Background(), 3, time.Hour, rec.handle)
+	defer p.Close()
+
+	for i := 1; i <= 7; i++ {
+		p.Add(i)
+	}
+	got := rec.snapshot()
+	if len(got) != 2 || len(got[0]) != 3 || len(got[1]) != 3 {
+		t.Fatalf("batches = %v, want two full batches of 3", got)
+	}
+}
+
+func TestBatchProcessorIntervalFlush(t *testing.T) {
+	var rec batchRecorder
+	p := NewBatchProcessor(context.Background(), 100, 20*time.Millisecond, rec.handle)
+	defer p.Close()
+
+	p.Add(1)
+	p.Add(2)
+
+	deadline := time.After(2 * time.Second)
+	for len(rec.snapshot()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("interval flush did not fire")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	if got := rec.snapshot(); len(got[0]) != 2 {
+		t.Errorf("flushed batch = %v, want [1 2]", got[0])
+	}
+}
+
+func TestBatchProcessorCloseFlushesRemainder(t *testing.T) {
+	var rec batchRecorder
+	p := NewBatchProcessor(context.Background(), 10, time.Hour, rec.handle)
+
+	p.Add(1)
+	p.Add(2)
+	p.Close()
+
+	got := rec.snapshot()
+	if len(got) != 1 || len(got[0]) != 2 {
+		t.Fatalf("batches after close = %v, want remainder flushed", got)
+	}
+
+	p.Add(3) // 关闭后的 Add 被忽略
+	if got := rec.snapshot(); len(got) != 1 {
+		t.Errorf("Add after close should be ignored, got %v", got)
+	}
+}
+
+func TestBatchProcessorContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var rec batchRecorder
+	p := NewBatchProcessor(ctx, 10, 10*time.Millisecond, rec.handle)
+
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	p.Add(1)
+	// 定时器已停，不应有自动 flush
+	time.Sleep(30 * time.Millisecond)
+	if got := rec.snapshot(); len(got) != 0 {
+		t.Errorf("batches after cancel = %v, want none", got)
+	}
+	p.Close() // Close 仍然兜底刷掉
+	if got := rec.snapshot(); len(got) != 1 {
+		t.Errorf("Close should flush remainder, got %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+package generics
+
+import "sync"
+
+// BatchGet 并发获取一批 key 对应的值，成功和失败分开收集。
+// maxConcurrency 小于等于 0 时不限并发。
+func BatchGet[K comparable, V any](keys []K, maxConcurrency int, fetch func(K) (V, error)) (map[K]V, map[K]error) {
+	values := make(map[K]V)
+	errs := make(map[K]error)
+	if len(keys) == 0 {
+		return values, errs
+	}
+
+	var sem chan struct{}
+	if maxConcurrency > 0 {
+		sem = make(chan struct{}, maxConcurrency)
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, k := range keys {
+		wg.Add(1)
+		go func(k K) {
+			defer wg.Done()
+			if sem != nil {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+			}
+			v, err := fetch(k)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[k] = err
+				return
+			}
+			values[k] = v
+		}(k)
+	}
+	wg.Wait()
+	return values, errs
+}
@@ -0,0 +1,54 @@
+package generics
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchGetResults(t *testing.T) {
+	keys := []int{1, 2, 3, 4}
+	values, errs := BatchGet(keys, 2, func(k int) (string, error) {
+		if k%2 == 0 {
+			return "", fmt.Errorf("key %d unavailable", k)
+		}
+		return fmt.Sprintf("v%d", k), nil
+	})
+	if len(values) != 2 || values[1] != "v1" || values[3] != "v3" {
+		t.Errorf("values = %v", values)
+	}
+	if len(errs) != 2 || errs[2] == nil || errs[4] == nil {
+		t.Errorf("errs = %v", errs)
+	}
+}
+
+func TestBatchGetConcurrencyCap(t *testing.T) {
+	var current, peak atomic.Int64
+	keys := make([]int, 20)
+	for i := range keys {
+		keys[i] = i
+	}
+	BatchGet(keys, 3, func(int) (int, error) {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		current.Add(-1)
+		return 0, nil
+	})
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", got)
+	}
+}
+
+func TestBatchGetEmpty(t *testing.T) {
+	values, errs := BatchGet(nil, 2, func(string) (int, error) { return 0, nil })
+	if len(values) != 0 || len(errs) != 0 {
+		t.Errorf("values = %v, errs = %v", values, errs)
+	}
+}
@@ -0,0 +1,76 @@
+package generics
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheAside 实现 Cache-Aside 模式的泛型封装：
+// Get 先查缓存，未命中时调用 loader 并回填。
+// 相同 key 的并发未命中只会触发一次 load（singleflight 效果）。
+type CacheAside[K comparable, V any] struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	entries  map[K]cacheEntry[V]
+	inflight map[K]*inflightCall[V]
+	now      func() time.Time
+}
+
+type cacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+type inflightCall[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// NewCacheAside 创建缓存，ttl <= 0 表示永不过期。
+func NewCacheAside[K comparable, V any](ttl time.Duration) *CacheAside[K, V] {
+	return &CacheAside[K, V]{
+		ttl:      ttl,
+		entries:  make(map[K]cacheEntry[V]),
+		inflight: make(map[K]*inflightCall[V]),
+		now:      time.Now,
+	}
+}
+
+// Get 返回缓存值，未命中时用 loader 加载并回填。
+// loader 返回错误时不回填，下次 Get 会重新加载。
+func (c *CacheAside[K, V]) Get(key K, loader func(K) (V, error)) (V, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && (c.ttl <= 0 || c.now().Before(e.expiresAt)) {
+		c.mu.Unlock()
+		return e.value, nil
+	}
+	// 已有同 key 的加载在途，等它完成
+	if call := c.inflight[key]; call != nil {
+		c.mu.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+	call := &inflightCall[V]{}
+	call.wg.Add(1)
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.val, call.err = loader(key)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.entries[key] = cacheEntry[V]{value: call.val, expiresAt: c.now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+	call.wg.Done()
+	return call.val, call.err
+}
+
+// Invalidate 删除一个 key，下次 Get 会重新加载。
+func (c *CacheAside[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
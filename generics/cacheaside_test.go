@@ -0,0 +1,77 @@
+package generics
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheAsideHitAndFill(t *testing.T) {
+	c := NewCacheAside[string, int](time.Minute)
+	loads := 0
+	loader := func(k string) (int, error) { loads++; return len(k), nil }
+
+	v, err := c.Get("hello", loader)
+	if err != nil || v != 5 {
+		t.Fatalf("Get = %v, %v", v, err)
+	}
+	v, _ = c.Get("hello", loader)
+	if v != 5 || loads != 1 {
+		t.Errorf("second Get should hit cache: v=%v loads=%d", v, loads)
+	}
+}
+
+func TestCacheAsideTTLExpiry(t *testing.T) {
+	c := NewCacheAside[string, int](time.Minute)
+	base := time.Unix(1000, 0)
+	c.now = func() time.Time { return base }
+
+	loads := 0
+	loader := func(k string) (int, error) { loads++; return loads, nil }
+
+	c.Get("k", loader)
+	base = base.Add(2 * time.Minute) // 过期
+	if v, _ := c.Get("k", loader); v != 2 || loads != 2 {
+		t.Errorf("expired entry should reload: v=%v loads=%d", v, loads)
+	}
+}
+
+func TestCacheAsideSingleflight(t *testing.T) {
+	c := NewCacheAside[string, int](time.Minute)
+	var loads int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loader := func(k string) (int, error) {
+		atomic.AddInt32(&loads, 1)
+		close(started)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, _ := c.Get("same", func(k string) (int, error) {
+				return loader(k)
+			})
+			results[i] = v
+		}(i)
+	}
+	<-started
+	time.Sleep(10 * time.Millisecond) // 让其余 goroutine 排到 inflight 等待
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&loads); got != 1 {
+		t.Errorf("loader called %d times, want 1", got)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("results[%d] = %d, want 42", i, v)
+		}
+	}
+}
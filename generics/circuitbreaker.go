@@ -0,0 +1,95 @@
+package generics
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// 熔断器三态，对应 Hystrix 的经典模型。
+type breakerState int
+
+const (
+	StateClosed   breakerState = iota // 正常放行
+	StateOpen                         // 熔断中，直接快速失败
+	StateHalfOpen                     // 冷却结束，放一个请求试探
+)
+
+// ErrCircuitOpen 熔断打开期间的快速失败错误。
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker 包装任意 func() error：
+// 连续失败达到阈值进入 open，冷却期后 half-open 试探，
+// 试探成功回到 closed，失败则重新 open。
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	state     breakerState
+	failures  int           // 连续失败次数
+	threshold int           // 触发熔断的连续失败阈值
+	cooldown  time.Duration // open -> half-open 的冷却时间
+	openedAt  time.Time
+
+	now func() time.Time // 测试时可替换
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// State 返回当前状态（会先结算冷却期）。
+func (cb *CircuitBreaker) State() breakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refresh()
+	return cb.state
+}
+
+// refresh 冷却期结束时把 open 切到 half-open，调用方需持有锁。
+func (cb *CircuitBreaker) refresh() {
+	if cb.state == StateOpen && cb.now().Sub(cb.openedAt) >= cb.cooldown {
+		cb.state = StateHalfOpen
+	}
+}
+
+// Call 执行 fn 并根据结果推进状态机。
+func (cb *CircuitBreaker) Call(fn func() error) error {
+	cb.mu.Lock()
+	cb.refresh()
+	if cb.state == StateOpen {
+		cb.mu.Unlock()
+		return ErrCircuitOpen
+	}
+	cb.mu.Unlock()
+
+	err := fn()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if err == nil {
+		// 成功：half-open 试探通过或正常请求，都回到 closed
+		cb.state = StateClosed
+		cb.failures = 0
+		return nil
+	}
+	cb.failures++
+	if cb.state == StateHalfOpen || cb.failures >= cb.threshold {
+		cb.state = StateOpen
+		cb.openedAt = cb.now()
+	}
+	return err
+}
+
+// Do 是带返回值的泛型版本，内部复用 Call 的状态机。
+func Do[T any](cb *CircuitBreaker, fn func() (T, error)) (T, error) {
+	var result T
+	err := cb.Call(func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,79 @@
+package generics
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	cb := NewCircuitBreaker(3, time.Minute)
+	boom := errors.New("boom")
+
+	for i := 0; i < 3; i++ {
+		if err := cb.Call(func() error { return boom }); !errors.Is(err, boom) {
+			t.Fatalf("call %d: err = %v", i, err)
+		}
+	}
+	if cb.State() != StateOpen {
+		t.Fatalf("state = %v, want open", cb.State())
+	}
+	// open 期间直接快速失败，fn 不会被调用
+	called := false
+	err := cb.Call(func() error { called = true; return nil })
+	if !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("err = %v, called = %v", err, called)
+	}
+}
+
+func TestCircuitBreakerHalfOpenAndRecover(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+	current := time.Now()
+	cb.now = func() time.Time { return current }
+
+	boom := errors.New("boom")
+	cb.Call(func() error { return boom })
+	cb.Call(func() error { return boom })
+	if cb.State() != StateOpen {
+		t.Fatalf("state = %v, want open", cb.State())
+	}
+
+	// 冷却期过后进入 half-open
+	current = current.Add(2 * time.Minute)
+	if cb.State() != StateHalfOpen {
+		t.Fatalf("state = %v, want half-open", cb.State())
+	}
+
+	// 试探成功，恢复 closed
+	if err := cb.Call(func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if cb.State() != StateClosed {
+		t.Errorf("state = %v, want closed after probe success", cb.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+	current := time.Now()
+	cb.now = func() time.Time { return current }
+
+	boom := errors.New("boom")
+	cb.Call(func() error { return boom })
+	cb.Call(func() error { return boom })
+	current = current.Add(2 * time.Minute)
+
+	// half-open 试探再失败，立即回到 open
+	cb.Call(func() error { return boom })
+	if cb.State() != StateOpen {
+		t.Errorf("state = %v, want open after probe failure", cb.State())
+	}
+}
+
+func TestDoReturnsValue(t *testing.T) {
+	cb := NewCircuitBreaker(3, time.Minute)
+	got, err := Do(cb, func() (string, error) { return "ok", nil })
+	if err != nil || got != "ok" {
+		t.Errorf("got = %q, err = %v", got, err)
+	}
+}
@@ -0,0 +1,24 @@
+package generics
+
+// CloneSlice 浅拷贝切片：元素本身直接复制，但底层数组独立，
+// 拷贝后对任一方 append/修改都不影响另一方。nil 返回 nil。
+func CloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(s))
+	copy(out, s)
+	return out
+}
+
+// CloneMap 浅拷贝 map，nil 返回 nil。
+func CloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	out := make(map[K]V, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,41 @@
+package generics
+
+import "testing"
+
+func TestCloneSliceIndependent(t *testing.T) {
+	src := []int{1, 2, 3}
+	dst := CloneSlice(src)
+	dst[0] = 99
+	if src[0] != 1 {
+		t.Errorf("src modified: %v", src)
+	}
+	if len(dst) != 3 || dst[1] != 2 {
+		t.Errorf("dst = %v", dst)
+	}
+}
+
+func TestCloneSliceNilAndEmpty(t *testing.T) {
+	if got := CloneSlice[int](nil); got != nil {
+		t.Errorf("CloneSlice(nil) = %v, want nil", got)
+	}
+	got := CloneSlice([]int{})
+	if got == nil || len(got) != 0 {
+		t.Errorf("CloneSlice(empty) = %v, want non-nil empty", got)
+	}
+}
+
+func TestCloneMapIndependent(t *testing.T) {
+	src := map[string]int{"a": 1}
+	dst := CloneMap(src)
+	dst["a"] = 99
+	dst["b"] = 2
+	if src["a"] != 1 || len(src) != 1 {
+		t.Errorf("src modified: %v", src)
+	}
+}
+
+func TestCloneMapNil(t *testing.T) {
+	if got := CloneMap[string, int](nil); got != nil {
+		t.Errorf("CloneMap(nil) = %v, want nil", got)
+	}
+}
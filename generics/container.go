@@ -0,0 +1,105 @@
+package generics
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Container 是一个极简依赖注入容器，按类型注册工厂、按类型解析。
+// Go 的方法不能带类型参数，所以 Register/Resolve 做成包级泛型函数。
+type Container struct {
+	mu        sync.Mutex
+	factories map[reflect.Type]containerEntry
+	instances map[reflect.Type]any  // 单例缓存
+	resolving map[reflect.Type]bool // 当前解析链，用来发现循环依赖
+}
+
+type containerEntry struct {
+	factory   func(*Container) any
+	singleton bool
+}
+
+func NewContainer() *Container {
+	return &Container{
+		factories: make(map[reflect.Type]containerEntry),
+		instances: make(map[reflect.Type]any),
+		resolving: make(map[reflect.Type]bool),
+	}
+}
+
+// typeOf 取 T 的反射类型作为注册键，接口类型也能正确拿到。
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// RegisterSingleton 注册单例：第一次 Resolve 时创建，之后复用同一实例。
+func RegisterSingleton[T any](c *Container, factory func(*Container) T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.factories[typeOf[T]()] = containerEntry{
+		factory:   func(c *Container) any { return factory(c) },
+		singleton: true,
+	}
+}
+
+// RegisterTransient 注册瞬态：每次 Resolve 都调工厂新建。
+func RegisterTransient[T any](c *Container, factory func(*Container) T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.factories[typeOf[T]()] = containerEntry{
+		factory:   func(c *Container) any { return factory(c) },
+		singleton: false,
+	}
+}
+
+// Resolve 解析 T 的实例；未注册或出现循环依赖时返回错误。
+func Resolve[T any](c *Container) (T, error) {
+	var zero T
+	v, err := c.resolve(typeOf[T]())
+	if err != nil {
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+// MustResolve 是 Resolve 的 panic 版本，适合启动期组装。
+func MustResolve[T any](c *Container) T {
+	v, err := Resolve[T](c)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *Container) resolve(t reflect.Type) (any, error) {
+	c.mu.Lock()
+	entry, ok := c.factories[t]
+	if !ok {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("container: type %s not registered", t)
+	}
+	if entry.singleton {
+		if v, done := c.instances[t]; done {
+			c.mu.Unlock()
+			return v, nil
+		}
+	}
+	if c.resolving[t] {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("container: circular dependency on %s", t)
+	}
+	c.resolving[t] = true
+	c.mu.Unlock()
+
+	// 工厂可能递归 Resolve 依赖，必须在锁外调用
+	v := entry.factory(c)
+
+	c.mu.Lock()
+	delete(c.resolving, t)
+	if entry.singleton {
+		c.instances[t] = v
+	}
+	c.mu.Unlock()
+	return v, nil
+}
@@ -0,0 +1,98 @@
+package generics
+
+import (
+	"strings"
+	"testing"
+)
+
+type containerConfig struct{ DSN string }
+
+type containerRepo struct{ cfg *containerConfig }
+
+func TestContainerSingleton(t *testing.T) {
+	c := NewContainer()
+	created := 0
+	RegisterSingleton(c, func(*Container) *containerConfig {
+		created++
+		return &containerConfig{DSN: "localhost"}
+	})
+
+	a := MustResolve[*containerConfig](c)
+	b := MustResolve[*containerConfig](c)
+	if a != b {
+		t.Error("singleton should return the same instance")
+	}
+	if created != 1 {
+		t.Errorf("factory called %d times, want 1", created)
+	}
+}
+
+func TestContainerTransient(t *testing.T) {
+	c := NewContainer()
+	RegisterTransient(c, func(*Container) *containerConfig {
+		return &containerConfig{}
+	})
+	if MustResolve[*containerConfig](c) == MustResolve[*containerConfig](c) {
+		t.Error("transient should create a new instance per resolve")
+	}
+}
+
+func TestContainerDependencyChain(t *testing.T) {
+	c := NewContainer()
+	RegisterSingleton(c, func(*Container) *containerConfig {
+		return &containerConfig{DSN: "db:3306"}
+	})
+	RegisterSingleton(c, func(c *Container) *containerRepo {
+		return &containerRepo{cfg: MustResolve[*containerConfig](c)}
+	})
+
+	repo := MustResolve[*containerRepo](c)
+	if repo.cfg.DSN != "db:3306" {
+		t.Errorf("repo.cfg = %+v", repo.cfg)
+	}
+}
+
+func TestContainerUnregistered(t *testing.T) {
+	c := NewContainer()
+	if _, err := Resolve[*containerRepo](c); err == nil ||
+		!strings.Contains(err.Error(), "not registered") {
+		t.Errorf("err = %v, want not registered", err)
+	}
+}
+
+type cycleA struct{ b *cycleB }
+type cycleB struct{ a *cycleA }
+
+func TestContainerCircularDependency(t *testing.T) {
+	c := NewContainer()
+	RegisterSingleton(c, func(c *Container) *cycleA {
+		b, _ := Resolve[*cycleB](c)
+		return &cycleA{b: b}
+	})
+	RegisterSingleton(c, func(c *Container) *cycleB {
+		a, err := Resolve[*cycleA](c)
+		if err != nil {
+			// 把循环依赖错误往上抛，最外层的 Resolve 能看到
+			panic(err)
+		}
+		return &cycleB{a: a}
+	})
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("want circular dependency detected")
+		}
+		if !strings.Contains(toString(r), "circular dependency") {
+			t.Errorf("recovered = %v", r)
+		}
+	}()
+	MustResolve[*cycleA](c)
+}
+
+func toString(v any) string {
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package generics
+
+// Dedup 过滤掉与前一个值相同的连续重复，非连续的重复会保留。
+// 输入关闭后输出随之关闭。
+func Dedup[T comparable](in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		var prev T
+		first := true
+		for v := range in {
+			if !first && v == prev {
+				continue
+			}
+			prev, first = v, false
+			out <- v
+		}
+	}()
+	return out
+}
+
+// DedupAll 去掉全部重复值，只保留每个值第一次出现。
+// 需要记住见过的所有值，无界流慎用。
+func DedupAll[T comparable](in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		seen := make(map[T]struct{})
+		for v := range in {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			out <- v
+		}
+	}()
+	return out
+}
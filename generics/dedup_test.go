@@ -0,0 +1,55 @@
+package generics
+
+import "testing"
+
+func feed[T any](vs ...T) <-chan T {
+	ch := make(chan T, len(vs))
+	for _, v := range vs {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+func drain[T any](ch <-chan T) []T {
+	var out []T
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestDedupConsecutive(t *testing.T) {
+	got := drain(Dedup(feed(1, 1, 2, 2, 2, 3, 1, 1)))
+	want := []int{1, 2, 3, 1} // 非连续的 1 保留
+	if len(got) != len(want) {
+		t.Fatalf("Dedup = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Dedup[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDedupAll(t *testing.T) {
+	got := drain(DedupAll(feed("a", "b", "a", "c", "b", "a")))
+	want := []string{"a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("DedupAll = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DedupAll[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDedupEmpty(t *testing.T) {
+	if got := drain(Dedup(feed[int]())); got != nil {
+		t.Errorf("Dedup(empty) = %v", got)
+	}
+	if got := drain(DedupAll(feed[int]())); got != nil {
+		t.Errorf("DedupAll(empty) = %v", got)
+	}
+}
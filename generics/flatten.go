@@ -0,0 +1,28 @@
+package generics
+
+// Flatten 把两层嵌套的切片拍平成一层，保持元素顺序。
+func Flatten[T any](s [][]T) []T {
+	n := 0
+	for _, inner := range s {
+		n += len(inner)
+	}
+	out := make([]T, 0, n)
+	for _, inner := range s {
+		out = append(out, inner...)
+	}
+	return out
+}
+
+// FlattenDeep 递归拍平任意深度的嵌套（元素是 []any 就继续往下拆）。
+// 泛型表达不了“任意深度”，所以这里用 interface 版本。
+func FlattenDeep(s []any) []any {
+	out := []any{}
+	for _, v := range s {
+		if inner, ok := v.([]any); ok {
+			out = append(out, FlattenDeep(inner)...)
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,34 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	got := Flatten([][]int{{1, 2}, {3}, {4, 5, 6}})
+	want := []int{1, 2, 3, 4, 5, 6}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten = %v, want %v", got, want)
+	}
+}
+
+func TestFlattenEmptyInner(t *testing.T) {
+	got := Flatten([][]string{{}, {"a"}, {}, {"b"}})
+	want := []string{"a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten = %v, want %v", got, want)
+	}
+	if got := Flatten[int](nil); len(got) != 0 {
+		t.Errorf("Flatten(nil) = %v", got)
+	}
+}
+
+func TestFlattenDeep(t *testing.T) {
+	nested := []any{1, []any{2, []any{3, 4}}, 5, []any{}}
+	got := FlattenDeep(nested)
+	want := []any{1, 2, 3, 4, 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FlattenDeep = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,36 @@
+package generics
+
+// Number 可求和的数值类型约束。
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// GroupBy 按 key 函数把切片分组，保持组内原有顺序。
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// CountBy 按 key 统计每组的元素个数。
+func CountBy[T any, K comparable](s []T, key func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, v := range s {
+		counts[key(v)]++
+	}
+	return counts
+}
+
+// SumBy 按 key 分组并对 value 求和，比如任务按状态统计工时。
+func SumBy[T any, K comparable, V Number](s []T, key func(T) K, value func(T) V) map[K]V {
+	sums := make(map[K]V)
+	for _, v := range s {
+		sums[key(v)] += value(v)
+	}
+	return sums
+}
@@ -0,0 +1,56 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+type task struct {
+	Status string
+	Hours  int
+}
+
+var sampleTasks = []task{
+	{"todo", 2},
+	{"done", 1},
+	{"todo", 3},
+	{"doing", 4},
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(sampleTasks, func(t task) string { return t.Status })
+	if len(groups) != 3 {
+		t.Fatalf("groups = %v", groups)
+	}
+	want := []task{{"todo", 2}, {"todo", 3}}
+	if !reflect.DeepEqual(groups["todo"], want) {
+		t.Errorf(`groups["todo"] = %v, want %v (order preserved)`, groups["todo"], want)
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	counts := CountBy(sampleTasks, func(t task) string { return t.Status })
+	want := map[string]int{"todo": 2, "done": 1, "doing": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("CountBy = %v, want %v", counts, want)
+	}
+}
+
+func TestSumBy(t *testing.T) {
+	sums := SumBy(sampleTasks,
+		func(t task) string { return t.Status },
+		func(t task) int { return t.Hours })
+	want := map[string]int{"todo": 5, "done": 1, "doing": 4}
+	if !reflect.DeepEqual(sums, want) {
+		t.Errorf("SumBy = %v, want %v", sums, want)
+	}
+}
+
+func TestGroupByEmpty(t *testing.T) {
+	if groups := GroupBy([]task{}, func(t task) string { return t.Status }); len(groups) != 0 {
+		t.Errorf("GroupBy(empty) = %v", groups)
+	}
+	if counts := CountBy([]task{}, func(t task) string { return t.Status }); len(counts) != 0 {
+		t.Errorf("CountBy(empty) = %v", counts)
+	}
+}
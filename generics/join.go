@@ -0,0 +1,18 @@
+package generics
+
+import "strings"
+
+// JoinFunc 把任意类型切片拼成字符串：f 负责把元素转成字符串，
+// 用 strings.Builder 一次分配完成，避免 += 的反复拷贝。
+func JoinFunc[T any](s []T, sep string, f func(T) string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(f(s[0]))
+	for _, v := range s[1:] {
+		b.WriteString(sep)
+		b.WriteString(f(v))
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package generics
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestJoinFuncStructs(t *testing.T) {
+	type item struct {
+		Name  string
+		Count int
+	}
+	items := []item{{"apple", 2}, {"pear", 1}}
+	got := JoinFunc(items, ", ", func(i item) string {
+		return fmt.Sprintf("%s x%d", i.Name, i.Count)
+	})
+	if got != "apple x2, pear x1" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestJoinFuncEmpty(t *testing.T) {
+	if got := JoinFunc(nil, ",", func(i int) string { return "x" }); got != "" {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestJoinFuncSingleNoSeparator(t *testing.T) {
+	got := JoinFunc([]int{7}, " | ", func(i int) string { return fmt.Sprint(i) })
+	if got != "7" {
+		t.Errorf("single = %q", got)
+	}
+}
@@ -0,0 +1,27 @@
+package generics
+
+import "sync"
+
+// KeyedOnce 按 key 的 sync.Once：同一 key 的函数只执行一次，
+// 适合按租户/按连接做一次性初始化。零值可用。
+type KeyedOnce[K comparable] struct {
+	mu    sync.Mutex
+	onces map[K]*sync.Once
+}
+
+// Do 对 key 执行 fn，同一 key 只有第一次调用会真正执行，
+// 并发调用时其他 goroutine 会等第一次执行完成（sync.Once 语义）。
+func (o *KeyedOnce[K]) Do(key K, fn func()) {
+	o.mu.Lock()
+	if o.onces == nil {
+		o.onces = make(map[K]*sync.Once)
+	}
+	once, ok := o.onces[key]
+	if !ok {
+		once = new(sync.Once)
+		o.onces[key] = once
+	}
+	o.mu.Unlock()
+
+	once.Do(fn)
+}
@@ -0,0 +1,47 @@
+package generics
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestKeyedOnceSameKeyRunsOnce(t *testing.T) {
+	var o KeyedOnce[string]
+	count := 0
+	for i := 0; i < 3; i++ {
+		o.Do("tenant-a", func() { count++ })
+	}
+	if count != 1 {
+		t.Errorf("executed %d times, want 1", count)
+	}
+}
+
+func TestKeyedOnceDifferentKeys(t *testing.T) {
+	var o KeyedOnce[int]
+	ran := map[int]bool{}
+	for _, k := range []int{1, 2, 3} {
+		k := k
+		o.Do(k, func() { ran[k] = true })
+	}
+	if len(ran) != 3 {
+		t.Errorf("ran = %v, want all three keys", ran)
+	}
+}
+
+func TestKeyedOnceConcurrent(t *testing.T) {
+	var o KeyedOnce[string]
+	var count atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.Do("k", func() { count.Add(1) })
+		}()
+	}
+	wg.Wait()
+	if count.Load() != 1 {
+		t.Errorf("executed %d times under concurrency, want 1", count.Load())
+	}
+}
@@ -0,0 +1,92 @@
+package generics
+
+// Lazy 是一个拉取式的惰性序列，灵感来自 Go 1.23 的 iter.Seq，
+// 这里做一个学习版：Map/Filter/Take 都只是组合 next 函数，
+// 直到 ToSlice（或手动拉取）才真正计算，不产生中间切片。
+type Lazy[T any] struct {
+	next func() (T, bool)
+}
+
+// FromSlice 从切片构造惰性序列。
+func FromSlice[T any](s []T) Lazy[T] {
+	i := 0
+	return Lazy[T]{next: func() (T, bool) {
+		if i >= len(s) {
+			var zero T
+			return zero, false
+		}
+		v := s[i]
+		i++
+		return v, true
+	}}
+}
+
+// Map 对每个元素应用 f（同类型变换）。跨类型的版本见 MapLazy。
+func (l Lazy[T]) Map(f func(T) T) Lazy[T] {
+	return Lazy[T]{next: func() (T, bool) {
+		v, ok := l.next()
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		return f(v), true
+	}}
+}
+
+// MapLazy 跨类型的 Map。Go 的方法不能引入新类型参数，只能做成包级函数。
+func MapLazy[T, U any](l Lazy[T], f func(T) U) Lazy[U] {
+	return Lazy[U]{next: func() (U, bool) {
+		v, ok := l.next()
+		if !ok {
+			var zero U
+			return zero, false
+		}
+		return f(v), true
+	}}
+}
+
+// Filter 只保留满足 pred 的元素。
+func (l Lazy[T]) Filter(pred func(T) bool) Lazy[T] {
+	return Lazy[T]{next: func() (T, bool) {
+		for {
+			v, ok := l.next()
+			if !ok {
+				var zero T
+				return zero, false
+			}
+			if pred(v) {
+				return v, true
+			}
+		}
+	}}
+}
+
+// Take 最多取前 n 个元素，之后不再拉取上游。
+func (l Lazy[T]) Take(n int) Lazy[T] {
+	taken := 0
+	return Lazy[T]{next: func() (T, bool) {
+		if taken >= n {
+			var zero T
+			return zero, false
+		}
+		v, ok := l.next()
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		taken++
+		return v, true
+	}}
+}
+
+// ToSlice 触发计算，物化为切片。
+func (l Lazy[T]) ToSlice() []T {
+	var out []T
+	for {
+		v, ok := l.next()
+		if !ok {
+			return out
+		}
+		out = append(out, v)
+	}
+}
@@ -0,0 +1,47 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLazyTakeShortCircuits(t *testing.T) {
+	calls := 0
+	got := FromSlice([]int{1, 2, 3, 4, 5}).
+		Map(func(v int) int { calls++; return v * 10 }).
+		Take(2).
+		ToSlice()
+
+	if !reflect.DeepEqual(got, []int{10, 20}) {
+		t.Errorf("got %v", got)
+	}
+	if calls != 2 {
+		t.Errorf("Map called %d times, want 2 (lazy evaluation)", calls)
+	}
+}
+
+func TestLazyMapFilterChain(t *testing.T) {
+	got := FromSlice([]int{1, 2, 3, 4, 5, 6}).
+		Filter(func(v int) bool { return v%2 == 0 }).
+		Map(func(v int) int { return v + 1 }).
+		ToSlice()
+	if !reflect.DeepEqual(got, []int{3, 5, 7}) {
+		t.Errorf("got %v, want [3 5 7]", got)
+	}
+}
+
+func TestMapLazyChangesType(t *testing.T) {
+	got := MapLazy(FromSlice([]int{1, 22, 333}), func(v int) string {
+		return "n"
+	}).ToSlice()
+	if len(got) != 3 || got[0] != "n" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestLazyEmpty(t *testing.T) {
+	got := FromSlice([]int{}).Map(func(v int) int { return v }).Take(3).ToSlice()
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
@@ -0,0 +1,29 @@
+package generics
+
+// MergeMaps 合并多个 map，后面的覆盖前面的，典型用法是配置叠加：
+// MergeMaps(defaults, fileConfig, envConfig)。输入不会被修改。
+func MergeMaps[K comparable, V any](maps ...map[K]V) map[K]V {
+	out := make(map[K]V)
+	for _, m := range maps {
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// MergeMapsWith 同 MergeMaps，但 key 冲突时交给 resolve 决定留哪个值，
+// old 是已合并的值，new 是后来者。
+func MergeMapsWith[K comparable, V any](resolve func(key K, old, new V) V, maps ...map[K]V) map[K]V {
+	out := make(map[K]V)
+	for _, m := range maps {
+		for k, v := range m {
+			if existing, ok := out[k]; ok {
+				out[k] = resolve(k, existing, v)
+				continue
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeMapsLaterWins(t *testing.T) {
+	defaults := map[string]int{"port": 8080, "timeout": 30}
+	override := map[string]int{"port": 9090}
+	got := MergeMaps(defaults, override)
+	want := map[string]int{"port": 9090, "timeout": 30}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// 输入不被修改
+	if defaults["port"] != 8080 {
+		t.Errorf("defaults modified: %v", defaults)
+	}
+}
+
+func TestMergeMapsWithResolver(t *testing.T) {
+	calls := 0
+	got := MergeMapsWith(func(key string, old, new int) int {
+		calls++
+		if old > new {
+			return old
+		}
+		return new
+	},
+		map[string]int{"a": 5, "b": 1},
+		map[string]int{"a": 3, "b": 9},
+	)
+	if calls != 2 {
+		t.Errorf("resolver called %d times, want 2", calls)
+	}
+	if got["a"] != 5 || got["b"] != 9 {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestMergeMapsEmptyInput(t *testing.T) {
+	got := MergeMaps[string, int]()
+	if got == nil || len(got) != 0 {
+		t.Errorf("got %v, want empty non-nil map", got)
+	}
+}
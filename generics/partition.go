@@ -0,0 +1,16 @@
+package generics
+
+// Partition 按条件把切片一分为二：满足 pred 的进 matched，
+// 其余进 unmatched，两边都保持原有顺序。
+func Partition[T any](s []T, pred func(T) bool) (matched, unmatched []T) {
+	matched = []T{}
+	unmatched = []T{}
+	for _, v := range s {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			unmatched = append(unmatched, v)
+		}
+	}
+	return matched, unmatched
+}
@@ -0,0 +1,38 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPartitionMixed(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	matched, unmatched := Partition([]int{1, 2, 3, 4, 5}, even)
+	if !reflect.DeepEqual(matched, []int{2, 4}) {
+		t.Errorf("matched = %v", matched)
+	}
+	if !reflect.DeepEqual(unmatched, []int{1, 3, 5}) {
+		t.Errorf("unmatched = %v", unmatched)
+	}
+}
+
+func TestPartitionAllMatch(t *testing.T) {
+	matched, unmatched := Partition([]int{2, 4}, func(v int) bool { return true })
+	if len(matched) != 2 || len(unmatched) != 0 {
+		t.Errorf("matched = %v, unmatched = %v", matched, unmatched)
+	}
+}
+
+func TestPartitionNoneMatch(t *testing.T) {
+	matched, unmatched := Partition([]int{1, 3}, func(v int) bool { return false })
+	if len(matched) != 0 || len(unmatched) != 2 {
+		t.Errorf("matched = %v, unmatched = %v", matched, unmatched)
+	}
+}
+
+func TestPartitionEmpty(t *testing.T) {
+	matched, unmatched := Partition(nil, func(v int) bool { return true })
+	if len(matched) != 0 || len(unmatched) != 0 {
+		t.Errorf("matched = %v, unmatched = %v", matched, unmatched)
+	}
+}
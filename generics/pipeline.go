@@ -0,0 +1,58 @@
+package generics
+
+// Pipeline 声明式地组装 channel 管道：
+// 每个 Stage 是一个 `<-chan T` 到 `<-chan T` 的变换，
+// Run 时按添加顺序串起来，goroutine 的启动和 channel 关闭由阶段自己负责。
+type Pipeline[T any] struct {
+	stages []func(in <-chan T) <-chan T
+}
+
+// NewPipeline 创建空管道。
+func NewPipeline[T any]() *Pipeline[T] {
+	return &Pipeline[T]{}
+}
+
+// Stage 追加一个阶段，返回自身便于链式调用。
+func (p *Pipeline[T]) Stage(stage func(in <-chan T) <-chan T) *Pipeline[T] {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Run 把所有阶段按顺序接到 source 上，返回最后一个阶段的输出。
+func (p *Pipeline[T]) Run(source <-chan T) <-chan T {
+	out := source
+	for _, stage := range p.stages {
+		out = stage(out)
+	}
+	return out
+}
+
+// MapStage 构造一个逐元素变换的阶段，省去手写 goroutine 样板。
+func MapStage[T any](f func(T) T) func(<-chan T) <-chan T {
+	return func(in <-chan T) <-chan T {
+		out := make(chan T)
+		go func() {
+			defer close(out)
+			for v := range in {
+				out <- f(v)
+			}
+		}()
+		return out
+	}
+}
+
+// FilterStage 构造一个过滤阶段。
+func FilterStage[T any](pred func(T) bool) func(<-chan T) <-chan T {
+	return func(in <-chan T) <-chan T {
+		out := make(chan T)
+		go func() {
+			defer close(out)
+			for v := range in {
+				if pred(v) {
+					out <- v
+				}
+			}
+		}()
+		return out
+	}
+}
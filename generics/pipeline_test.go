@@ -0,0 +1,57 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func sourceChan(vals ...int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, v := range vals {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+func collect[T any](ch <-chan T) []T {
+	var out []T
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestPipelineMultiStage(t *testing.T) {
+	out := NewPipeline[int]().
+		Stage(MapStage(func(v int) int { return v * v })).
+		Stage(FilterStage(func(v int) bool { return v%2 == 0 })).
+		Run(sourceChan(1, 2, 3, 4))
+
+	got := collect(out)
+	want := []int{4, 16}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPipelineStageOrder(t *testing.T) {
+	// 先 +1 再 *10 和先 *10 再 +1 结果不同，验证阶段按添加顺序执行
+	out := NewPipeline[int]().
+		Stage(MapStage(func(v int) int { return v + 1 })).
+		Stage(MapStage(func(v int) int { return v * 10 })).
+		Run(sourceChan(1))
+
+	if got := collect(out); got[0] != 20 {
+		t.Errorf("got %v, want [20] (add then multiply)", got)
+	}
+}
+
+func TestPipelineNoStages(t *testing.T) {
+	out := NewPipeline[int]().Run(sourceChan(7))
+	if got := collect(out); !reflect.DeepEqual(got, []int{7}) {
+		t.Errorf("got %v, want passthrough", got)
+	}
+}
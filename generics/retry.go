@@ -0,0 +1,76 @@
+package generics
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryStrategy 决定第 attempt 次失败后等多久再试。
+// attempt 从 1 开始计；返回 ok=false 表示不再重试。
+type RetryStrategy interface {
+	NextDelay(attempt int) (time.Duration, bool)
+}
+
+// FixedDelay 固定间隔重试，最多 MaxAttempts 次。
+type FixedDelay struct {
+	Interval    time.Duration
+	MaxAttempts int
+}
+
+func (f FixedDelay) NextDelay(attempt int) (time.Duration, bool) {
+	if attempt >= f.MaxAttempts {
+		return 0, false
+	}
+	return f.Interval, true
+}
+
+// ExponentialBackoff 指数退避：Base、2*Base、4*Base……封顶 Max。
+type ExponentialBackoff struct {
+	Base        time.Duration
+	Max         time.Duration
+	MaxAttempts int
+}
+
+func (e ExponentialBackoff) NextDelay(attempt int) (time.Duration, bool) {
+	if attempt >= e.MaxAttempts {
+		return 0, false
+	}
+	d := e.Base << (attempt - 1)
+	if e.Max > 0 && d > e.Max {
+		d = e.Max
+	}
+	return d, true
+}
+
+// JitteredBackoff 在指数退避的基础上加随机抖动，
+// 实际延迟在 [d/2, d) 之间，避免一批客户端同时重试。
+type JitteredBackoff struct {
+	ExponentialBackoff
+}
+
+func (j JitteredBackoff) NextDelay(attempt int) (time.Duration, bool) {
+	d, ok := j.ExponentialBackoff.NextDelay(attempt)
+	if !ok {
+		return 0, false
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// retrySleep 可在测试中替换以免真实等待。
+var retrySleep = time.Sleep
+
+// Retry 按策略重试 fn 直到成功或策略喊停，返回最后一次的结果。
+func Retry[T any](s RetryStrategy, fn func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		v, err := fn()
+		if err == nil {
+			return v, nil
+		}
+		d, ok := s.NextDelay(attempt)
+		if !ok {
+			return v, err
+		}
+		retrySleep(d)
+	}
+}
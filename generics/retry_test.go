@@ -0,0 +1,104 @@
+package generics
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func delaySequence(s RetryStrategy, n int) []time.Duration {
+	var seq []time.Duration
+	for i := 1; i <= n; i++ {
+		d, ok := s.NextDelay(i)
+		if !ok {
+			break
+		}
+		seq = append(seq, d)
+	}
+	return seq
+}
+
+func TestFixedDelaySequence(t *testing.T) {
+	s := FixedDelay{Interval: 100 * time.Millisecond, MaxAttempts: 3}
+	got := delaySequence(s, 10)
+	want := []time.Duration{100 * time.Millisecond, 100 * time.Millisecond}
+	if len(got) != len(want) {
+		t.Fatalf("sequence = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("delay[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestExponentialBackoffSequence(t *testing.T) {
+	s := ExponentialBackoff{Base: time.Second, Max: 4 * time.Second, MaxAttempts: 6}
+	got := delaySequence(s, 10)
+	want := []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("sequence = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("delay[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestJitteredBackoffRange(t *testing.T) {
+	s := JitteredBackoff{ExponentialBackoff{Base: time.Second, MaxAttempts: 5}}
+	for i := 0; i < 50; i++ {
+		d, ok := s.NextDelay(2) // 退避基准 2s
+		if !ok {
+			t.Fatal("NextDelay(2) should allow retry")
+		}
+		if d < time.Second || d > 2*time.Second {
+			t.Fatalf("jittered delay = %v, want in [1s, 2s]", d)
+		}
+	}
+	if _, ok := s.NextDelay(5); ok {
+		t.Error("attempt 5 should stop")
+	}
+}
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	var slept []time.Duration
+	retrySleep = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { retrySleep = time.Sleep }()
+
+	calls := 0
+	v, err := Retry(FixedDelay{Interval: time.Millisecond, MaxAttempts: 5}, func() (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errors.New("transient")
+		}
+		return 42, nil
+	})
+	if err != nil || v != 42 {
+		t.Fatalf("Retry = (%d, %v), want (42, nil)", v, err)
+	}
+	if calls != 3 || len(slept) != 2 {
+		t.Errorf("calls = %d, sleeps = %d, want 3 and 2", calls, len(slept))
+	}
+}
+
+func TestRetryStopsAtMaxAttempts(t *testing.T) {
+	retrySleep = func(time.Duration) {}
+	defer func() { retrySleep = time.Sleep }()
+
+	calls := 0
+	wantErr := errors.New("always")
+	_, err := Retry(FixedDelay{Interval: time.Millisecond, MaxAttempts: 3}, func() (string, error) {
+		calls++
+		return "", wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
@@ -0,0 +1,56 @@
+package generics
+
+import "sync"
+
+// RingBuffer 固定容量的并发安全环形缓冲区，
+// 适合保存“最近 N 条”日志或事件：满了以后新数据覆盖最旧的。
+type RingBuffer[T any] struct {
+	mu    sync.Mutex
+	buf   []T
+	start int // 最旧一条的下标
+	size  int
+}
+
+// NewRingBuffer 创建容量为 capacity 的缓冲区，capacity 必须大于 0。
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("generics: ring buffer capacity must be positive")
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity)}
+}
+
+// Push 追加一条记录，缓冲区满时覆盖最旧的一条。
+func (r *RingBuffer[T]) Push(v T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size < len(r.buf) {
+		r.buf[(r.start+r.size)%len(r.buf)] = v
+		r.size++
+		return
+	}
+	r.buf[r.start] = v
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+// Items 返回按插入顺序（旧到新）的快照，与内部存储不共享。
+func (r *RingBuffer[T]) Items() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]T, r.size)
+	for i := 0; i < r.size; i++ {
+		out[i] = r.buf[(r.start+i)%len(r.buf)]
+	}
+	return out
+}
+
+// Len 返回当前条数。
+func (r *RingBuffer[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.size
+}
+
+// Cap 返回容量。
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.buf)
+}
@@ -0,0 +1,63 @@
+package generics
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRingBufferBelowCapacity(t *testing.T) {
+	r := NewRingBuffer[int](5)
+	r.Push(1)
+	r.Push(2)
+	r.Push(3)
+	if got := r.Items(); len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("Items = %v", got)
+	}
+	if r.Len() != 3 || r.Cap() != 5 {
+		t.Errorf("Len = %d, Cap = %d", r.Len(), r.Cap())
+	}
+}
+
+func TestRingBufferOverwritesOldest(t *testing.T) {
+	r := NewRingBuffer[string](3)
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		r.Push(s)
+	}
+	got := r.Items()
+	want := []string{"c", "d", "e"}
+	if len(got) != len(want) {
+		t.Fatalf("Items = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Items[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRingBufferConcurrentPush(t *testing.T) {
+	r := NewRingBuffer[int](64)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(base int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Push(base*100 + j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if got := r.Len(); got != 64 {
+		t.Errorf("Len = %d, want full buffer 64", got)
+	}
+}
+
+func TestRingBufferInvalidCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewRingBuffer(0) should panic")
+		}
+	}()
+	NewRingBuffer[int](0)
+}
@@ -0,0 +1,26 @@
+package generics
+
+import "cmp"
+
+// BinarySearch 在升序切片里二分查找 target：
+// 命中返回 (索引, true)；未命中返回 (应插入的位置, false)，
+// 插入位置语义与 sort.SearchInts 一致。
+func BinarySearch[T cmp.Ordered](s []T, target T) (int, bool) {
+	return BinarySearchFunc(s, target, cmp.Compare[T])
+}
+
+// BinarySearchFunc 自定义比较版本，compare 返回负/零/正表示 a 小于/等于/大于 b。
+// 有重复元素时返回第一个相等元素的索引。
+func BinarySearchFunc[T, K any](s []T, target K, compare func(T, K) int) (int, bool) {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if compare(s[mid], target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	found := lo < len(s) && compare(s[lo], target) == 0
+	return lo, found
+}
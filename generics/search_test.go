@@ -0,0 +1,54 @@
+package generics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBinarySearchHit(t *testing.T) {
+	s := []int{1, 3, 5, 7, 9}
+	for i, v := range s {
+		if idx, ok := BinarySearch(s, v); !ok || idx != i {
+			t.Errorf("search %d = (%d, %v), want (%d, true)", v, idx, ok, i)
+		}
+	}
+}
+
+func TestBinarySearchMissReturnsInsertPos(t *testing.T) {
+	s := []int{1, 3, 5, 7}
+	tests := []struct {
+		target, pos int
+	}{
+		{0, 0}, {2, 1}, {6, 3}, {8, 4},
+	}
+	for _, tt := range tests {
+		idx, ok := BinarySearch(s, tt.target)
+		if ok || idx != tt.pos {
+			t.Errorf("search %d = (%d, %v), want (%d, false)", tt.target, idx, ok, tt.pos)
+		}
+	}
+}
+
+func TestBinarySearchEmpty(t *testing.T) {
+	if idx, ok := BinarySearch([]string{}, "x"); ok || idx != 0 {
+		t.Errorf("empty = (%d, %v)", idx, ok)
+	}
+}
+
+func TestBinarySearchDuplicatesFindsFirst(t *testing.T) {
+	s := []int{1, 2, 2, 2, 3}
+	if idx, ok := BinarySearch(s, 2); !ok || idx != 1 {
+		t.Errorf("duplicates = (%d, %v), want (1, true)", idx, ok)
+	}
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	type user struct{ Name string }
+	users := []user{{"alice"}, {"bob"}, {"carol"}}
+	idx, ok := BinarySearchFunc(users, "bob", func(u user, name string) int {
+		return strings.Compare(u.Name, name)
+	})
+	if !ok || idx != 1 {
+		t.Errorf("func search = (%d, %v), want (1, true)", idx, ok)
+	}
+}
@@ -0,0 +1,55 @@
+package generics
+
+import "fmt"
+
+// StateMachine 基于 map 的通用状态机：
+// 把 (当前状态, 事件) -> 下一状态 的流转表配置出来，
+// 未登记的流转一律报错，避免 switch 嵌套写漏分支。
+type StateMachine[S comparable, E comparable] struct {
+	transitions map[S]map[E]S
+}
+
+func NewStateMachine[S comparable, E comparable]() *StateMachine[S, E] {
+	return &StateMachine[S, E]{transitions: make(map[S]map[E]S)}
+}
+
+// AddTransition 登记一条流转：处于 from 状态时收到 event 进入 to。
+func (m *StateMachine[S, E]) AddTransition(from S, event E, to S) {
+	if m.transitions[from] == nil {
+		m.transitions[from] = make(map[E]S)
+	}
+	m.transitions[from][event] = to
+}
+
+// Trigger 在 current 状态上触发 event，返回新状态；未定义的流转报错。
+func (m *StateMachine[S, E]) Trigger(current S, event E) (S, error) {
+	if to, ok := m.transitions[current][event]; ok {
+		return to, nil
+	}
+	var zero S
+	return zero, fmt.Errorf("no transition from %v on event %v", current, event)
+}
+
+// OrderStatus 用订单状态演示状态机用法。
+type OrderStatus string
+
+const (
+	OrderCreated   OrderStatus = "created"
+	OrderPaid      OrderStatus = "paid"
+	OrderShipped   OrderStatus = "shipped"
+	OrderDone      OrderStatus = "done"
+	OrderCancelled OrderStatus = "cancelled"
+)
+
+// NewOrderStateMachine 订单的标准流转：
+// created -pay-> paid -ship-> shipped -receive-> done，
+// created/paid 可以 cancel。
+func NewOrderStateMachine() *StateMachine[OrderStatus, string] {
+	m := NewStateMachine[OrderStatus, string]()
+	m.AddTransition(OrderCreated, "pay", OrderPaid)
+	m.AddTransition(OrderCreated, "cancel", OrderCancelled)
+	m.AddTransition(OrderPaid, "ship", OrderShipped)
+	m.AddTransition(OrderPaid, "cancel", OrderCancelled)
+	m.AddTransition(OrderShipped, "receive", OrderDone)
+	return m
+}
@@ -0,0 +1,62 @@
+package generics
+
+import "testing"
+
+func TestOrderStateMachineHappyPath(t *testing.T) {
+	m := NewOrderStateMachine()
+	state := OrderCreated
+	for _, step := range []struct {
+		event string
+		want  OrderStatus
+	}{
+		{"pay", OrderPaid},
+		{"ship", OrderShipped},
+		{"receive", OrderDone},
+	} {
+		next, err := m.Trigger(state, step.event)
+		if err != nil {
+			t.Fatalf("%s on %s: %v", step.event, state, err)
+		}
+		if next != step.want {
+			t.Fatalf("%s -> %s, want %s", state, next, step.want)
+		}
+		state = next
+	}
+}
+
+func TestStateMachineInvalidTransition(t *testing.T) {
+	m := NewOrderStateMachine()
+	// 没付款不能发货
+	if _, err := m.Trigger(OrderCreated, "ship"); err == nil {
+		t.Error("created + ship should fail")
+	}
+	// 发货后不能取消
+	if _, err := m.Trigger(OrderShipped, "cancel"); err == nil {
+		t.Error("shipped + cancel should fail")
+	}
+	// 终态没有任何流转
+	if _, err := m.Trigger(OrderDone, "pay"); err == nil {
+		t.Error("done + pay should fail")
+	}
+}
+
+func TestStateMachineMultipleEvents(t *testing.T) {
+	m := NewOrderStateMachine()
+	// 同一状态不同事件走不同分支
+	if next, _ := m.Trigger(OrderPaid, "ship"); next != OrderShipped {
+		t.Errorf("paid + ship = %s", next)
+	}
+	if next, _ := m.Trigger(OrderPaid, "cancel"); next != OrderCancelled {
+		t.Errorf("paid + cancel = %s", next)
+	}
+}
+
+func TestStateMachineIntStates(t *testing.T) {
+	// 状态和事件都可以是任意 comparable 类型
+	m := NewStateMachine[int, rune]()
+	m.AddTransition(0, '+', 1)
+	m.AddTransition(1, '+', 2)
+	if next, err := m.Trigger(1, '+'); err != nil || next != 2 {
+		t.Errorf("next = %d, err = %v", next, err)
+	}
+}
@@ -0,0 +1,56 @@
+package generics
+
+import "sync"
+
+// CacheStats 缓存命中统计快照。
+type CacheStats struct {
+	Hits    int64
+	Misses  int64
+	Entries int
+}
+
+// HitRate 命中率，没有任何访问时为 0。
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// StatsCache 带命中统计的并发安全缓存，用来直观看缓存效果。
+type StatsCache[K comparable, V any] struct {
+	mu     sync.Mutex
+	data   map[K]V
+	hits   int64
+	misses int64
+}
+
+func NewStatsCache[K comparable, V any]() *StatsCache[K, V] {
+	return &StatsCache[K, V]{data: make(map[K]V)}
+}
+
+func (c *StatsCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.data[key]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return v, ok
+}
+
+func (c *StatsCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+}
+
+// Stats 返回当前统计的快照。
+func (c *StatsCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Entries: len(c.data)}
+}
@@ -0,0 +1,62 @@
+package generics
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestStatsCacheCounts(t *testing.T) {
+	c := NewStatsCache[string, int]()
+	c.Put("a", 1)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Error("a should hit")
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should miss")
+	}
+	c.Get("a")
+
+	stats := c.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestStatsCacheHitRate(t *testing.T) {
+	c := NewStatsCache[int, int]()
+	if got := c.Stats().HitRate(); got != 0 {
+		t.Errorf("empty hit rate = %v, want 0", got)
+	}
+	c.Put(1, 1)
+	c.Get(1) // hit
+	c.Get(2) // miss
+	c.Get(3) // miss
+	c.Get(1) // hit
+	if got := c.Stats().HitRate(); got != 0.5 {
+		t.Errorf("hit rate = %v, want 0.5", got)
+	}
+}
+
+func TestStatsCacheConcurrent(t *testing.T) {
+	c := NewStatsCache[string, int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprint(i % 5)
+			c.Put(key, i)
+			c.Get(key)
+		}(i)
+	}
+	wg.Wait()
+	stats := c.Stats()
+	if stats.Hits+stats.Misses != 50 {
+		t.Errorf("total accesses = %d, want 50", stats.Hits+stats.Misses)
+	}
+	if stats.Entries != 5 {
+		t.Errorf("entries = %d, want 5", stats.Entries)
+	}
+}
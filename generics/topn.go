@@ -0,0 +1,20 @@
+package generics
+
+import "sort"
+
+// TopNByGroup 先按 key 分组，再在组内按 less 排序取前 n 个，
+// 典型报表需求："每个分类的销量前三"。n 超过组大小时整组返回。
+func TopNByGroup[T any, K comparable](s []T, key func(T) K, less func(a, b T) bool, n int) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	for k, items := range groups {
+		sort.SliceStable(items, func(i, j int) bool { return less(items[i], items[j]) })
+		if len(items) > n {
+			groups[k] = items[:n]
+		}
+	}
+	return groups
+}
@@ -0,0 +1,50 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+type sale struct {
+	Category string
+	Amount   int
+}
+
+func TestTopNByGroup(t *testing.T) {
+	sales := []sale{
+		{"book", 30}, {"book", 10}, {"book", 20},
+		{"food", 5}, {"food", 50},
+	}
+	got := TopNByGroup(sales,
+		func(s sale) string { return s.Category },
+		func(a, b sale) bool { return a.Amount > b.Amount }, // 金额倒序
+		2)
+
+	wantBook := []sale{{"book", 30}, {"book", 20}}
+	if !reflect.DeepEqual(got["book"], wantBook) {
+		t.Errorf("book = %v, want %v", got["book"], wantBook)
+	}
+	wantFood := []sale{{"food", 50}, {"food", 5}}
+	if !reflect.DeepEqual(got["food"], wantFood) {
+		t.Errorf("food = %v, want %v", got["food"], wantFood)
+	}
+}
+
+func TestTopNByGroupLargerThanGroup(t *testing.T) {
+	got := TopNByGroup([]int{3, 1},
+		func(int) string { return "all" },
+		func(a, b int) bool { return a < b },
+		10)
+	if !reflect.DeepEqual(got["all"], []int{1, 3}) {
+		t.Errorf("got = %v", got["all"])
+	}
+}
+
+func TestTopNByGroupEmpty(t *testing.T) {
+	got := TopNByGroup(nil,
+		func(int) int { return 0 },
+		func(a, b int) bool { return a < b }, 3)
+	if len(got) != 0 {
+		t.Errorf("got = %v, want empty map", got)
+	}
+}
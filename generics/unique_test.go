@@ -0,0 +1,41 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUniqueKeepsFirstOccurrenceOrder(t *testing.T) {
+	got := Unique([]int{3, 1, 3, 2, 1, 3})
+	want := []int{3, 1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unique = %v, want %v", got, want)
+	}
+}
+
+func TestUniqueNoDuplicates(t *testing.T) {
+	in := []string{"a", "b", "c"}
+	got := Unique(in)
+	if !reflect.DeepEqual(got, in) {
+		t.Errorf("Unique = %v, want unchanged %v", got, in)
+	}
+}
+
+func TestUniqueEmpty(t *testing.T) {
+	if got := Unique([]int{}); len(got) != 0 {
+		t.Errorf("Unique(empty) = %v", got)
+	}
+}
+
+func TestUniqueByKey(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	in := []user{{1, "alice"}, {2, "bob"}, {1, "alice-dup"}, {3, "carol"}}
+	got := UniqueBy(in, func(u user) int { return u.ID })
+	want := []user{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UniqueBy = %v, want %v", got, want)
+	}
+}
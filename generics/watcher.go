@@ -0,0 +1,116 @@
+package generics
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// Watcher 轮询配置文件的修改时间，变化时重新解析并回调。
+// 不引第三方 fsnotify，轮询对配置文件这种低频变化足够了。
+type Watcher[T any] struct {
+	path     string
+	interval time.Duration
+	parse    func(data []byte) (T, error)
+	onChange func(cfg T)
+
+	mu      sync.Mutex
+	current T
+	modTime time.Time
+	stop    chan struct{}
+}
+
+// NewWatcher 创建监听器并立即加载一次初始配置。
+func NewWatcher[T any](path string, interval time.Duration,
+	parse func([]byte) (T, error), onChange func(T)) (*Watcher[T], error) {
+	w := &Watcher[T]{path: path, interval: interval, parse: parse, onChange: onChange}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	w.current = cfg
+	w.modTime = info.ModTime()
+	return w, nil
+}
+
+// Current 返回当前生效的配置。
+func (w *Watcher[T]) Current() T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Start 启动后台轮询，重复调用只会启动一次。
+func (w *Watcher[T]) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		return
+	}
+	w.stop = make(chan struct{})
+	go w.loop(w.stop)
+}
+
+// Stop 停止轮询，之后文件再变也不触发回调。
+func (w *Watcher[T]) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+func (w *Watcher[T]) loop(stop chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// check 对比 mtime，变了就重新解析；解析失败保留旧配置不回调。
+func (w *Watcher[T]) check() {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	changed := info.ModTime().After(w.modTime)
+	w.mu.Unlock()
+	if !changed {
+		return
+	}
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return
+	}
+	cfg, err := w.parse(data)
+
+	w.mu.Lock()
+	w.modTime = info.ModTime()
+	if err != nil {
+		w.mu.Unlock()
+		return
+	}
+	w.current = cfg
+	onChange := w.onChange
+	w.mu.Unlock()
+
+	if onChange != nil {
+		onChange(cfg)
+	}
+}
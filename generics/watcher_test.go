@@ -0,0 +1,114 @@
+package generics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Name string `json:"name"`
+}
+
+func writeConfig(t *testing.T, path, name string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(`{"name":"`+name+`"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// mtime 精度有限，手动往后拨保证能检测到变化
+	future := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestWatcher(t *testing.T, path string, reloads *atomic.Int64) *Watcher[testConfig] {
+	t.Helper()
+	w, err := NewWatcher(path, 5*time.Millisecond,
+		func(data []byte) (testConfig, error) {
+			var c testConfig
+			err := json.Unmarshal(data, &c)
+			return c, err
+		},
+		func(testConfig) { reloads.Add(1) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met in time")
+}
+
+func TestWatcherReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfig(t, path, "v1")
+
+	var reloads atomic.Int64
+	w := newTestWatcher(t, path, &reloads)
+	if w.Current().Name != "v1" {
+		t.Fatalf("initial = %+v", w.Current())
+	}
+	w.Start()
+	defer w.Stop()
+
+	writeConfig(t, path, "v2")
+	waitFor(t, func() bool { return w.Current().Name == "v2" })
+	if reloads.Load() == 0 {
+		t.Error("onChange was not called")
+	}
+}
+
+func TestWatcherKeepsOldConfigOnParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfig(t, path, "good")
+
+	var reloads atomic.Int64
+	w := newTestWatcher(t, path, &reloads)
+	w.Start()
+	defer w.Stop()
+
+	if err := os.WriteFile(path, []byte("{broken"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Second)
+	_ = os.Chtimes(path, future, future)
+
+	time.Sleep(50 * time.Millisecond)
+	if w.Current().Name != "good" {
+		t.Errorf("config = %+v, want old value kept", w.Current())
+	}
+	if reloads.Load() != 0 {
+		t.Errorf("onChange called %d times for broken config", reloads.Load())
+	}
+}
+
+func TestWatcherStop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfig(t, path, "v1")
+
+	var reloads atomic.Int64
+	w := newTestWatcher(t, path, &reloads)
+	w.Start()
+	w.Stop()
+
+	writeConfig(t, path, "v2")
+	time.Sleep(50 * time.Millisecond)
+	if got := w.Current().Name; got != "v1" {
+		t.Errorf("config after Stop = %q, want v1", got)
+	}
+	if reloads.Load() != 0 {
+		t.Errorf("onChange called after Stop")
+	}
+}
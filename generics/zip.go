@@ -0,0 +1,32 @@
+// Package generics 收集泛型练习中沉淀下来的通用切片/映射工具。
+package generics
+
+// Pair 表示一对关联的值，常见于 map 的 key/value 配对场景。
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip 将两个切片按下标配对，长度取较短的一方。
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	pairs := make([]Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		pairs[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return pairs
+}
+
+// Unzip 是 Zip 的逆操作，把配对切片拆回两个切片。
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	a := make([]A, len(pairs))
+	b := make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i] = p.First
+		b[i] = p.Second
+	}
+	return a, b
+}
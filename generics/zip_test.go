@@ -0,0 +1,38 @@
+package generics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestZipEqualLength(t *testing.T) {
+	got := Zip([]string{"a", "b"}, []int{1, 2})
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Zip = %v, want %v", got, want)
+	}
+}
+
+func TestZipTakesShorter(t *testing.T) {
+	got := Zip([]string{"a", "b", "c"}, []int{1})
+	want := []Pair[string, int]{{"a", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Zip = %v, want %v", got, want)
+	}
+}
+
+func TestZipEmpty(t *testing.T) {
+	if got := Zip([]string{}, []int{1, 2}); len(got) != 0 {
+		t.Errorf("Zip with empty slice = %v, want empty", got)
+	}
+	if got := Zip[string, int](nil, nil); len(got) != 0 {
+		t.Errorf("Zip(nil, nil) = %v, want empty", got)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := Unzip([]Pair[string, int]{{"a", 1}, {"b", 2}})
+	if !reflect.DeepEqual(a, []string{"a", "b"}) || !reflect.DeepEqual(b, []int{1, 2}) {
+		t.Errorf("Unzip = %v, %v", a, b)
+	}
+}
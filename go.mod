@@ -0,0 +1,3 @@
+module github.com/onceMisery/go-study
+
+go 1.21
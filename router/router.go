@@ -0,0 +1,101 @@
+// Package router 演示如何不依赖 gin 自己实现一个带路径参数
+// 和通配符的路由器。web-api 里有个更精简的内部版本，
+// 这里是可以独立使用的完整实现。
+package router
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Router 按注册顺序匹配路由，支持三种 segment：
+//
+//	静态:  /users/list
+//	参数:  /users/:id        -> params["id"]
+//	通配:  /files/*path      -> params["path"] 匹配剩余全部路径
+type Router struct {
+	routes []route
+}
+
+type route struct {
+	method  string
+	parts   []string
+	handler http.HandlerFunc
+}
+
+func New() *Router {
+	return &Router{}
+}
+
+// Handle 注册一条路由。通配符只能出现在 pattern 末尾。
+func (r *Router) Handle(method, pattern string, h http.HandlerFunc) {
+	r.routes = append(r.routes, route{
+		method:  method,
+		parts:   split(pattern),
+		handler: h,
+	})
+}
+
+// Match 查找匹配的 handler，并返回提取出的路径参数。
+// 没有任何路由匹配时 ok 为 false。
+func (r *Router) Match(method, path string) (h http.HandlerFunc, params map[string]string, ok bool) {
+	parts := split(path)
+	for _, rt := range r.routes {
+		if rt.method != method {
+			continue
+		}
+		if params, ok := match(rt.parts, parts); ok {
+			return rt.handler, params, true
+		}
+	}
+	return nil, nil, false
+}
+
+// ServeHTTP 让 Router 可以直接挂到 http.Server 上，未匹配返回 404。
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h, params, ok := r.Match(req.Method, req.URL.Path)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	// 参数通过 header 不合适，这里简单塞进 URL query 供 handler 读取。
+	q := req.URL.Query()
+	for k, v := range params {
+		q.Set(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+	h(w, req)
+}
+
+func split(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func match(pattern, parts []string) (map[string]string, bool) {
+	params := map[string]string{}
+	for i, pp := range pattern {
+		if strings.HasPrefix(pp, "*") {
+			// 通配符吃掉剩余所有 segment（可以为空）。
+			params[pp[1:]] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if i >= len(parts) {
+			return nil, false
+		}
+		if strings.HasPrefix(pp, ":") {
+			params[pp[1:]] = parts[i]
+			continue
+		}
+		if pp != parts[i] {
+			return nil, false
+		}
+	}
+	if len(parts) != len(pattern) {
+		return nil, false
+	}
+	return params, true
+}
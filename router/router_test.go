@@ -0,0 +1,65 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func noop(w http.ResponseWriter, r *http.Request) {}
+
+func TestMatchStatic(t *testing.T) {
+	r := New()
+	r.Handle("GET", "/users/list", noop)
+
+	if _, _, ok := r.Match("GET", "/users/list"); !ok {
+		t.Error("static route should match")
+	}
+	if _, _, ok := r.Match("POST", "/users/list"); ok {
+		t.Error("wrong method should not match")
+	}
+	if _, _, ok := r.Match("GET", "/users"); ok {
+		t.Error("shorter path should not match")
+	}
+}
+
+func TestMatchParams(t *testing.T) {
+	r := New()
+	r.Handle("GET", "/users/:id/posts/:postId", noop)
+
+	_, params, ok := r.Match("GET", "/users/42/posts/7")
+	if !ok {
+		t.Fatal("param route should match")
+	}
+	if params["id"] != "42" || params["postId"] != "7" {
+		t.Errorf("params = %v", params)
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	r := New()
+	r.Handle("GET", "/files/*path", noop)
+
+	_, params, ok := r.Match("GET", "/files/a/b/c.txt")
+	if !ok {
+		t.Fatal("wildcard route should match")
+	}
+	if params["path"] != "a/b/c.txt" {
+		t.Errorf("path = %q, want a/b/c.txt", params["path"])
+	}
+
+	if _, params, ok = r.Match("GET", "/files/"); !ok || params["path"] != "" {
+		t.Errorf("empty wildcard: ok=%v params=%v", ok, params)
+	}
+}
+
+func TestServeHTTPNotFound(t *testing.T) {
+	r := New()
+	r.Handle("GET", "/ping", noop)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/missing", nil))
+	if rec.Code != 404 {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// handleUserActivity 管理员查看某用户的活跃度：
+// 最近登录时间、任务创建/完成数、近 7 天创建趋势和评论数。
+func (s *server) handleUserActivity(w http.ResponseWriter, r *http.Request, admin *User) {
+	id, err := strconv.ParseInt(param(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	target := s.store.getUser(id)
+	if target == nil {
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	tasks := s.store.listTasksOf(id)
+	created, completed := 0, 0
+	createdByDay := map[string]int{}
+	for _, t := range tasks {
+		created++
+		if t.Status == "done" {
+			completed++
+		}
+		createdByDay[t.CreatedAt.Format("2006-01-02")]++
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"user_id":              id,
+		"last_login_at":        target.LastLoginAt,
+		"tasks_created":        created,
+		"tasks_completed":      completed,
+		"tasks_created_by_day": createdByDay,
+		"comments":             len(s.store.listCommentsOf(id)),
+	})
+}
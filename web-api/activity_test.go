@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUserActivityStats(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	userToken, userID := registerAndLogin(t, srv, "worker")
+
+	tasks := makeTasks(t, srv, userToken, "a", "b", "c")
+	srv.store.mu.Lock()
+	srv.store.tasks[tasks[0].ID].Status = "done"
+	srv.store.mu.Unlock()
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", tasks[1].ID), userToken,
+		map[string]string{"content": "hi"})
+
+	rec := doJSON(srv, "GET", fmt.Sprintf("/admin/users/%d/activity", userID), adminToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	stats := decodeBody[map[string]any](t, rec)
+	if stats["tasks_created"] != float64(3) {
+		t.Errorf("tasks_created = %v, want 3", stats["tasks_created"])
+	}
+	if stats["tasks_completed"] != float64(1) {
+		t.Errorf("tasks_completed = %v, want 1", stats["tasks_completed"])
+	}
+	if stats["comments"] != float64(1) {
+		t.Errorf("comments = %v, want 1", stats["comments"])
+	}
+	if stats["last_login_at"] == nil {
+		t.Error("last_login_at should be set after login")
+	}
+
+	// 普通用户无权访问
+	rec = doJSON(srv, "GET", fmt.Sprintf("/admin/users/%d/activity", userID), userToken, nil)
+	if rec.Code != 403 {
+		t.Errorf("non-admin: status = %d, want 403", rec.Code)
+	}
+}
+
+func TestUserActivityZeroValues(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	// 只注册不登录不会有 last_login_at
+	rec := doJSON(srv, "POST", "/register", "", map[string]string{
+		"username": "idle", "password": "secret123",
+	})
+	idle := decodeBody[User](t, rec)
+
+	rec = doJSON(srv, "GET", fmt.Sprintf("/admin/users/%d/activity", idle.ID), adminToken, nil)
+	stats := decodeBody[map[string]any](t, rec)
+	if stats["tasks_created"] != float64(0) || stats["comments"] != float64(0) {
+		t.Errorf("idle user stats = %v, want zeros", stats)
+	}
+	if stats["last_login_at"] != nil {
+		t.Errorf("last_login_at = %v, want null", stats["last_login_at"])
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// handleAdminListUsers 管理员用户列表，支持搜索、排序和状态过滤：
+//   - search：用户名或邮箱的子串匹配（不区分大小写）
+//   - sort_by：created_at / username / last_login，默认 created_at
+//   - order：asc / desc，默认 asc
+//   - status：active（登录过）/ inactive（从未登录）
+func (s *server) handleAdminListUsers(w http.ResponseWriter, r *http.Request, u *User) {
+	q := r.URL.Query()
+	sortBy := q.Get("sort_by")
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if sortBy != "created_at" && sortBy != "username" && sortBy != "last_login" {
+		writeError(w, http.StatusBadRequest, "sort_by must be created_at, username or last_login")
+		return
+	}
+	order := q.Get("order")
+	if order == "" {
+		order = "asc"
+	}
+	if order != "asc" && order != "desc" {
+		writeError(w, http.StatusBadRequest, "order must be asc or desc")
+		return
+	}
+	status := q.Get("status")
+	if status != "" && status != "active" && status != "inactive" {
+		writeError(w, http.StatusBadRequest, "status must be active or inactive")
+		return
+	}
+
+	users := s.store.listUsers()
+	search := strings.ToLower(q.Get("search"))
+	out := []*User{}
+	for _, user := range users {
+		if search != "" &&
+			!strings.Contains(strings.ToLower(user.Username), search) &&
+			!strings.Contains(strings.ToLower(user.Email), search) {
+			continue
+		}
+		if status == "active" && user.LastLoginAt == nil {
+			continue
+		}
+		if status == "inactive" && user.LastLoginAt != nil {
+			continue
+		}
+		out = append(out, user)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		less := false
+		switch sortBy {
+		case "username":
+			less = out[i].Username < out[j].Username
+		case "last_login":
+			// 没登录过的排最前（当作零时间）
+			a, b := out[i].LastLoginAt, out[j].LastLoginAt
+			switch {
+			case a == nil:
+				less = b != nil
+			case b == nil:
+				less = false
+			default:
+				less = a.Before(*b)
+			}
+		default: // created_at
+			less = out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		if order == "desc" {
+			return !less && !userSortEqual(out[i], out[j], sortBy)
+		}
+		return less
+	})
+
+	page, limit := pageParams(r, 20)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items":      paginate(out, page, limit),
+		"total":      len(out),
+		"pagination": NewPagination(page, limit, len(out)),
+	})
+}
+
+// userSortEqual 判断两个用户在当前排序键上相等，desc 反转时保持稳定。
+func userSortEqual(a, b *User, sortBy string) bool {
+	switch sortBy {
+	case "username":
+		return a.Username == b.Username
+	case "last_login":
+		if a.LastLoginAt == nil || b.LastLoginAt == nil {
+			return a.LastLoginAt == b.LastLoginAt
+		}
+		return a.LastLoginAt.Equal(*b.LastLoginAt)
+	default:
+		return a.CreatedAt.Equal(b.CreatedAt)
+	}
+}
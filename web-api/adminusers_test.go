@@ -0,0 +1,85 @@
+package main
+
+import "testing"
+
+func adminUserNames(t *testing.T, srv *server, token, query string) []string {
+	t.Helper()
+	rec := doJSON(srv, "GET", "/admin/users"+query, token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("list users %q: status = %d, body = %s", query, rec.Code, rec.Body.String())
+	}
+	resp := decodeBody[struct {
+		Items []User `json:"items"`
+	}](t, rec)
+	names := []string{}
+	for _, u := range resp.Items {
+		names = append(names, u.Username)
+	}
+	return names
+}
+
+func TestAdminListUsersSearchAndFilter(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin") // 第一个注册的是管理员
+	registerAndLogin(t, srv, "alice")
+	// carol 只注册不登录，属于 inactive
+	rec := doJSON(srv, "POST", "/register", "", map[string]string{
+		"username": "carol", "password": "secret123", "email": "carol@example.com",
+	})
+	if rec.Code != 201 {
+		t.Fatalf("register carol: %d", rec.Code)
+	}
+
+	// 搜索用户名
+	if got := adminUserNames(t, srv, adminToken, "?search=ali"); len(got) != 1 || got[0] != "alice" {
+		t.Errorf("search ali = %v", got)
+	}
+	// 搜索邮箱
+	if got := adminUserNames(t, srv, adminToken, "?search=carol@example"); len(got) != 1 || got[0] != "carol" {
+		t.Errorf("search email = %v", got)
+	}
+	// 状态过滤
+	if got := adminUserNames(t, srv, adminToken, "?status=inactive"); len(got) != 1 || got[0] != "carol" {
+		t.Errorf("inactive = %v", got)
+	}
+	if got := adminUserNames(t, srv, adminToken, "?status=active"); len(got) != 2 {
+		t.Errorf("active = %v", got)
+	}
+}
+
+func TestAdminListUsersSort(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "mallory")
+	registerAndLogin(t, srv, "alice")
+	registerAndLogin(t, srv, "zack")
+
+	got := adminUserNames(t, srv, adminToken, "?sort_by=username")
+	want := []string{"alice", "mallory", "zack"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sort username asc = %v", got)
+		}
+	}
+
+	got = adminUserNames(t, srv, adminToken, "?sort_by=username&order=desc")
+	if got[0] != "zack" || got[2] != "alice" {
+		t.Errorf("sort username desc = %v", got)
+	}
+
+	// 组合：搜索 + 排序
+	registerAndLogin(t, srv, "albert")
+	got = adminUserNames(t, srv, adminToken, "?search=al&sort_by=username&order=desc")
+	if len(got) != 3 || got[0] != "mallory" {
+		t.Errorf("combined = %v", got)
+	}
+}
+
+func TestAdminListUsersForbiddenForNonAdmin(t *testing.T) {
+	srv := newTestServer()
+	registerAndLogin(t, srv, "admin")
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "GET", "/admin/users", token, nil)
+	if rec.Code != 403 {
+		t.Errorf("non-admin: status = %d, want 403", rec.Code)
+	}
+}
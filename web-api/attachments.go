@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxAttachmentSize 附件大小上限，练习项目直接放内存里。
+const maxAttachmentSize = 5 << 20 // 5MB
+
+// Attachment 任务附件元信息，文件内容存内存不对外暴露。
+type Attachment struct {
+	ID          int64     `json:"id"`
+	TaskID      int64     `json:"task_id"`
+	UserID      int64     `json:"user_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int       `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	data []byte
+}
+
+// handleUploadAttachment 上传附件，用文件头 magic bytes 做深度类型校验：
+// 光看扩展名/声明的 Content-Type 不可信，伪装文件一律拒绝。
+func (s *server) handleUploadAttachment(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	if err := r.ParseMultipartForm(maxAttachmentSize); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid multipart form")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxAttachmentSize+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+	if len(data) == 0 {
+		writeError(w, http.StatusBadRequest, "empty file")
+		return
+	}
+	if len(data) > maxAttachmentSize {
+		writeError(w, http.StatusRequestEntityTooLarge, "file too large")
+		return
+	}
+
+	detected := http.DetectContentType(data)
+	declared := header.Header.Get("Content-Type")
+	if declared != "" && !contentTypeMatches(declared, detected) {
+		writeError(w, http.StatusBadRequest,
+			"declared content type "+declared+" does not match detected "+detected)
+		return
+	}
+
+	a := s.store.createAttachment(t.ID, u.ID, header.Filename, detected, data)
+	writeJSON(w, http.StatusCreated, a)
+}
+
+// contentTypeMatches 判断声明类型和探测类型是否一致。
+// DetectContentType 会带 charset 参数，且文本类一律报 text/plain，
+// 所以同主类型（text/xxx vs text/yyy）也算通过，跨主类型才算伪装。
+func contentTypeMatches(declared, detected string) bool {
+	declared = strings.TrimSpace(strings.SplitN(declared, ";", 2)[0])
+	detected = strings.TrimSpace(strings.SplitN(detected, ";", 2)[0])
+	if declared == detected {
+		return true
+	}
+	declaredMain := strings.SplitN(declared, "/", 2)[0]
+	detectedMain := strings.SplitN(detected, "/", 2)[0]
+	return declaredMain == detectedMain
+}
+
+func (s *server) handleListAttachments(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.store.listAttachmentsOfTask(t.ID))
+}
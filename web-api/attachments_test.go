@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+)
+
+// pngHeader 真实 PNG 文件的 magic bytes。
+var pngHeader = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0, 0, 0, 0}
+
+// uploadFile 构造 multipart 上传请求，declared 是客户端声明的 Content-Type。
+func uploadFile(srv *server, token string, taskID int64, filename, declared string, content []byte) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition",
+		fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+	if declared != "" {
+		h.Set("Content-Type", declared)
+	}
+	part, _ := mw.CreatePart(h)
+	part.Write(content)
+	mw.Close()
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/tasks/%d/attachments", taskID), &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUploadAttachmentValid(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	rec := uploadFile(srv, token, task.ID, "pic.png", "image/png", pngHeader)
+	if rec.Code != 201 {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	a := decodeBody[Attachment](t, rec)
+	if a.ContentType != "image/png" || a.Size != len(pngHeader) {
+		t.Errorf("attachment = %+v", a)
+	}
+
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/attachments", task.ID), token, nil)
+	if list := decodeBody[[]Attachment](t, rec); len(list) != 1 {
+		t.Errorf("attachments = %v", list)
+	}
+}
+
+func TestUploadAttachmentDisguisedRejected(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	// 文本内容改名成 .png 并声明 image/png，magic bytes 对不上
+	rec := uploadFile(srv, token, task.ID, "evil.png", "image/png",
+		[]byte("#!/bin/sh\nrm -rf /\n"))
+	if rec.Code != 400 {
+		t.Fatalf("disguised file: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestUploadAttachmentEmptyRejected(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	rec := uploadFile(srv, token, task.ID, "empty.txt", "text/plain", nil)
+	if rec.Code != 400 {
+		t.Fatalf("empty file: status = %d, want 400", rec.Code)
+	}
+}
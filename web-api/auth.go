@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// tokenSecret 签名密钥，练习项目写死即可。
+var tokenSecret = []byte("go-study-web-api-secret")
+
+const tokenTTL = 24 * time.Hour
+
+// errExpiredToken 区分“过期”和其他校验失败，handler 可以给出机器可读的码。
+var errExpiredToken = errors.New("token expired")
+
+type tokenClaims struct {
+	UserID    int64  `json:"uid"`
+	ExpiresAt int64  `json:"exp"`
+	JTI       string `json:"jti"` // 令牌唯一标识，登出时进黑名单用
+}
+
+// signToken 生成 payload.signature 形式的访问令牌（简化版 JWT）。
+func signToken(userID int64, ttl time.Duration) string {
+	claims := tokenClaims{
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+		JTI:       newJTI(),
+	}
+	payload, _ := json.Marshal(claims)
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, tokenSecret)
+	mac.Write([]byte(body))
+	sig := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	return body + "." + sig
+}
+
+// parseToken 校验签名和过期时间，返回完整 claims。
+func parseToken(token string) (tokenClaims, error) {
+	var claims tokenClaims
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return claims, errors.New("malformed token")
+	}
+	mac := hmac.New(sha256.New, tokenSecret)
+	mac.Write([]byte(body))
+	want := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(sig), []byte(want)) {
+		return claims, errors.New("bad signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return claims, errors.New("malformed token")
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, errors.New("malformed token")
+	}
+	if time.Now().Unix() > claims.ExpiresAt {
+		return claims, errExpiredToken
+	}
+	return claims, nil
+}
+
+func hashPassword(pw string) string {
+	sum := sha256.Sum256([]byte(pw))
+	return hex.EncodeToString(sum[:])
+}
+
+// currentUser 从 Authorization: Bearer 头解析出登录用户。
+func (s *server) currentUser(r *http.Request) (*User, error) {
+	auth := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok {
+		return nil, errors.New("missing bearer token")
+	}
+	claims, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if s.revoked.contains(claims.JTI) {
+		return nil, errors.New("token revoked")
+	}
+	u := s.store.getUser(claims.UserID)
+	if u == nil {
+		return nil, errors.New("user not found")
+	}
+	return u, nil
+}
+
+// requireAdmin 包装只有管理员能访问的 handler。
+func (s *server) requireAdmin(h func(w http.ResponseWriter, r *http.Request, u *User)) http.HandlerFunc {
+	return s.requireAuth(func(w http.ResponseWriter, r *http.Request, u *User) {
+		if !u.IsAdmin {
+			writeError(w, http.StatusForbidden, "admin only")
+			return
+		}
+		h(w, r, u)
+	})
+}
+
+// requireAuth 包装需要登录的 handler。
+func (s *server) requireAuth(h func(w http.ResponseWriter, r *http.Request, u *User)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		u, err := s.currentUser(r)
+		if errors.Is(err, errExpiredToken) {
+			writeErrCode(w, ErrTokenExpired)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, "unauthorized: "+err.Error())
+			return
+		}
+		h(w, r, u)
+	}
+}
+
+func (s *server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Username == "" || req.Password == "" {
+		writeError(w, http.StatusBadRequest, "username and password are required")
+		return
+	}
+	u, err := s.store.createUser(req.Username, req.Email, hashPassword(req.Password))
+	if err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, u)
+}
+
+func (s *server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username    string `json:"username"`
+		Password    string `json:"password"`
+		TOTPCode    string `json:"totp_code"`
+		CaptchaID   string `json:"captcha_id"`
+		CaptchaCode string `json:"captcha_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	// 连续失败过多后必须先通过验证码，防脚本暴力破解
+	if s.guard.needsCaptcha(req.Username) {
+		if req.CaptchaID == "" {
+			writeError(w, http.StatusUnauthorized, "captcha required")
+			return
+		}
+		if !s.captchas.verify(req.CaptchaID, req.CaptchaCode) {
+			writeError(w, http.StatusUnauthorized, "invalid captcha")
+			return
+		}
+	}
+	u := s.store.getUserByName(req.Username)
+	if u == nil || u.PasswordHash != hashPassword(req.Password) {
+		s.guard.recordFailure(req.Username)
+		writeError(w, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+	if u.TOTPEnabled && !verifyTOTP(u.TOTPSecret, req.TOTPCode, time.Now()) {
+		s.guard.recordFailure(req.Username)
+		writeError(w, http.StatusUnauthorized, "invalid totp code")
+		return
+	}
+	s.guard.reset(req.Username)
+	s.store.recordLogin(u.ID)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"token":         signToken(u.ID, s.accessTokenTTL),
+		"refresh_token": s.store.issueRefreshToken(u.ID, s.refreshTokenTTL),
+		"user":          u,
+	})
+}
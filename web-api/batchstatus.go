@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// notifyAggregator 把一次批量操作里发给同一用户的多条通知聚合成一条，
+// 避免"批量完成 20 个任务"刷出 20 条通知。
+type notifyAggregator struct {
+	counts map[int64]int // 关注者用户 ID -> 涉及的任务数
+}
+
+func newNotifyAggregator() *notifyAggregator {
+	return &notifyAggregator{counts: make(map[int64]int)}
+}
+
+// add 记录一条待发通知，先不落库。
+func (a *notifyAggregator) add(userID int64) {
+	a.counts[userID]++
+}
+
+// flush 按人发出聚合后的通知，message 里的 %d 会被替换成任务数。
+func (a *notifyAggregator) flush(s *store, format string) {
+	for uid, n := range a.counts {
+		s.createNotification(uid, 0, fmt.Sprintf(format, n))
+	}
+}
+
+// validStatus 任务状态只允许三个值，和 Task.Status 的注释保持一致。
+func validStatus(status string) bool {
+	return status == "todo" || status == "doing" || status == "done"
+}
+
+// handleBatchStatus 批量修改任务状态，关注者只收到一条聚合通知。
+func (s *server) handleBatchStatus(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		TaskIDs []int64 `json:"task_ids"`
+		Status  string  `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if len(req.TaskIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "task_ids is required")
+		return
+	}
+	if !validStatus(req.Status) {
+		writeError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+
+	agg := newNotifyAggregator()
+	updated := []int64{}
+	for _, id := range req.TaskIDs {
+		t := s.store.getTask(id)
+		if t == nil || t.UserID != u.ID {
+			continue
+		}
+		s.store.setTaskStatus(id, req.Status)
+		updated = append(updated, id)
+		for _, uid := range s.store.watchersOf(id) {
+			if uid != u.ID {
+				agg.add(uid)
+			}
+		}
+	}
+	agg.flush(s.store, "%d 个任务状态变更为 "+req.Status)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"updated": updated,
+		"count":   len(updated),
+	})
+}
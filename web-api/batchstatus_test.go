@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBatchStatusAggregatedNotification(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, _ := registerAndLogin(t, srv, "alice")
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+
+	tasks := makeTasks(t, srv, aliceToken, "a", "b", "c")
+	ids := []int64{}
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+		rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/watch", task.ID), bobToken, nil)
+		if rec.Code != 200 {
+			t.Fatalf("watch: status = %d", rec.Code)
+		}
+	}
+
+	rec := doJSON(srv, "POST", "/tasks/batch-status", aliceToken,
+		map[string]any{"task_ids": ids, "status": "done"})
+	if rec.Code != 200 {
+		t.Fatalf("batch-status: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	resp := decodeBody[map[string]any](t, rec)
+	if resp["count"] != float64(3) {
+		t.Errorf("count = %v, want 3", resp["count"])
+	}
+
+	for _, id := range ids {
+		if task := srv.store.getTask(id); task.Status != "done" {
+			t.Errorf("task %d status = %q, want done", id, task.Status)
+		}
+	}
+
+	// bob 关注了 3 个任务，但只收到一条聚合通知
+	rec = doJSON(srv, "GET", "/notifications", bobToken, nil)
+	notices := decodeBody[[]Notification](t, rec)
+	if len(notices) != 1 {
+		t.Fatalf("notifications = %d, want 1 aggregated", len(notices))
+	}
+	if notices[0].Message != "3 个任务状态变更为 done" {
+		t.Errorf("message = %q", notices[0].Message)
+	}
+}
+
+func TestBatchStatusInvalidStatus(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	rec := doJSON(srv, "POST", "/tasks/batch-status", token,
+		map[string]any{"task_ids": []int64{task.ID}, "status": "archived"})
+	if rec.Code != 400 {
+		t.Errorf("invalid status: status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// handleCalendarToken 为当前用户签发（或返回已有的）日历订阅 token。
+// 它是独立于登录令牌的只读凭证，便于日历软件长期定期拉取。
+func (s *server) handleCalendarToken(w http.ResponseWriter, r *http.Request, u *User) {
+	token := s.store.calendarTokenFor(u.ID)
+	writeJSON(w, http.StatusOK, map[string]string{
+		"token": token,
+		"url":   "/tasks/calendar.ics?token=" + token,
+	})
+}
+
+// handleCalendarFeed 输出 iCalendar 格式的任务到期日历，
+// 每个设置了 due_date 的任务生成一条 VEVENT。
+func (s *server) handleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	userID, ok := s.store.userByCalendarToken(token)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid calendar token")
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//go-study//web-api//CN\r\n")
+	for _, task := range s.store.listTasksOf(userID) {
+		if task.DueDate == nil {
+			continue
+		}
+		due := task.DueDate.UTC().Format("20060102T150405Z")
+		b.WriteString("BEGIN:VEVENT\r\n")
+		fmt.Fprintf(&b, "UID:task-%d@go-study\r\n", task.ID)
+		fmt.Fprintf(&b, "DTSTART:%s\r\n", due)
+		fmt.Fprintf(&b, "SUMMARY:%s\r\n", icalEscape(task.Title))
+		b.WriteString("END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// icalEscape 按 RFC 5545 转义文本字段里的特殊字符。
+func icalEscape(s string) string {
+	r := strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\n", "\\n")
+	return r.Replace(s)
+}
+
+func randomToken() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	due := time.Date(2026, 9, 15, 10, 0, 0, 0, time.UTC)
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "with due", "due_date": due})
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "no due"})
+
+	rec := doJSON(srv, "GET", "/users/me/calendar-token", token, nil)
+	calToken := decodeBody[map[string]string](t, rec)["token"]
+	if calToken == "" {
+		t.Fatal("empty calendar token")
+	}
+
+	rec = doJSON(srv, "GET", "/tasks/calendar.ics?token="+calToken, "", nil)
+	if rec.Code != 200 {
+		t.Fatalf("feed status = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "BEGIN:VCALENDAR\r\n") || !strings.Contains(body, "END:VCALENDAR") {
+		t.Errorf("not a VCALENDAR document:\n%s", body)
+	}
+	if strings.Count(body, "BEGIN:VEVENT") != 1 {
+		t.Errorf("want exactly 1 VEVENT (only tasks with due_date), got:\n%s", body)
+	}
+	if !strings.Contains(body, "SUMMARY:with due") {
+		t.Errorf("missing task summary:\n%s", body)
+	}
+	if !strings.Contains(body, "DTSTART:20260915T100000Z") {
+		t.Errorf("missing DTSTART:\n%s", body)
+	}
+}
+
+func TestCalendarFeedInvalidToken(t *testing.T) {
+	srv := newTestServer()
+	rec := doJSON(srv, "GET", "/tasks/calendar.ics?token=bogus", "", nil)
+	if rec.Code != 401 {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+}
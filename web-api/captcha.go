@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 连续失败这么多次后，登录必须带验证码。
+const captchaThreshold = 3
+
+// captchaStore 带 TTL 的验证码存储。
+// 练习项目不引入图片库，用算术题代替图片，原理一致。
+type captchaStore struct {
+	mu    sync.Mutex
+	items map[string]captchaItem
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+type captchaItem struct {
+	answer    string
+	expiresAt time.Time
+}
+
+func newCaptchaStore(ttl time.Duration) *captchaStore {
+	return &captchaStore{
+		items: make(map[string]captchaItem),
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// create 生成一道算术题，返回 id 和题面。
+func (cs *captchaStore) create() (id, question string) {
+	a, b := rand.Intn(10), rand.Intn(10)
+	id = randomToken()
+	cs.mu.Lock()
+	cs.items[id] = captchaItem{
+		answer:    fmt.Sprint(a + b),
+		expiresAt: cs.now().Add(cs.ttl),
+	}
+	cs.mu.Unlock()
+	return id, fmt.Sprintf("%d + %d = ?", a, b)
+}
+
+// verify 校验并一次性消费验证码。
+func (cs *captchaStore) verify(id, code string) bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	item, ok := cs.items[id]
+	if !ok {
+		return false
+	}
+	delete(cs.items, id) // 无论对错只能用一次
+	return cs.now().Before(item.expiresAt) && item.answer == code
+}
+
+// loginGuard 记录每个用户名的连续失败次数。
+type loginGuard struct {
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+func newLoginGuard() *loginGuard {
+	return &loginGuard{failures: make(map[string]int)}
+}
+
+func (g *loginGuard) needsCaptcha(username string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.failures[username] >= captchaThreshold
+}
+
+func (g *loginGuard) recordFailure(username string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures[username]++
+}
+
+func (g *loginGuard) reset(username string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.failures, username)
+}
+
+// handleCaptcha 签发一个新验证码。
+func (s *server) handleCaptcha(w http.ResponseWriter, r *http.Request) {
+	id, question := s.captchas.create()
+	writeJSON(w, http.StatusOK, map[string]string{
+		"captcha_id": id,
+		"question":   question,
+	})
+}
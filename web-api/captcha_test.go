@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func solveCaptcha(t *testing.T, question string) string {
+	t.Helper()
+	var a, b int
+	if _, err := fmt.Sscanf(question, "%d + %d = ?", &a, &b); err != nil {
+		t.Fatalf("unexpected question %q: %v", question, err)
+	}
+	return fmt.Sprint(a + b)
+}
+
+func TestLoginRequiresCaptchaAfterFailures(t *testing.T) {
+	srv := newTestServer()
+	registerAndLogin(t, srv, "alice")
+
+	for i := 0; i < captchaThreshold; i++ {
+		rec := doJSON(srv, "POST", "/login", "", map[string]string{
+			"username": "alice", "password": "wrong",
+		})
+		if rec.Code != 401 {
+			t.Fatalf("bad password: status = %d", rec.Code)
+		}
+	}
+
+	// 超过阈值后即使密码正确也要验证码
+	rec := doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123",
+	})
+	if rec.Code != 401 || !strings.Contains(rec.Body.String(), "captcha required") {
+		t.Fatalf("want captcha required, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// 错误验证码被拒
+	rec = doJSON(srv, "GET", "/auth/captcha", "", nil)
+	c := decodeBody[map[string]string](t, rec)
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123",
+		"captcha_id": c["captcha_id"], "captcha_code": "nope",
+	})
+	if rec.Code != 401 || !strings.Contains(rec.Body.String(), "invalid captcha") {
+		t.Fatalf("want invalid captcha, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// 正确验证码 + 正确密码通过，之后失败计数清零
+	rec = doJSON(srv, "GET", "/auth/captcha", "", nil)
+	c = decodeBody[map[string]string](t, rec)
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123",
+		"captcha_id": c["captcha_id"], "captcha_code": solveCaptcha(t, c["question"]),
+	})
+	if rec.Code != 200 {
+		t.Fatalf("login with captcha: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123",
+	})
+	if rec.Code != 200 {
+		t.Fatalf("failure counter should reset after success, got %d", rec.Code)
+	}
+}
+
+func TestCaptchaSingleUse(t *testing.T) {
+	cs := newCaptchaStore(0)
+	id, _ := cs.create()
+	cs.verify(id, "whatever")
+	if cs.verify(id, "whatever") {
+		t.Error("captcha should be consumed after first verify")
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultCommentLockTTL 编辑锁的有效期，到期自动失效。
+const defaultCommentLockTTL = 2 * time.Minute
+
+type commentLock struct {
+	userID  int64
+	expires time.Time
+}
+
+// lockStore 评论编辑锁，防止两个人同时编辑同一条评论。
+type lockStore struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	locks map[int64]commentLock // 评论 ID -> 持有者
+}
+
+func newLockStore(ttl time.Duration) *lockStore {
+	return &lockStore{ttl: ttl, locks: make(map[int64]commentLock)}
+}
+
+// acquire 尝试给 userID 上锁。自己重复上锁会续期；
+// 被别人持有且未过期时失败，返回当前持有者。
+func (ls *lockStore) acquire(commentID, userID int64) (ok bool, holder int64, expires time.Time) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	now := time.Now()
+	if l, exists := ls.locks[commentID]; exists && l.userID != userID && l.expires.After(now) {
+		return false, l.userID, l.expires
+	}
+	l := commentLock{userID: userID, expires: now.Add(ls.ttl)}
+	ls.locks[commentID] = l
+	return true, userID, l.expires
+}
+
+// release 释放自己的锁，别人的锁碰不动。
+func (ls *lockStore) release(commentID, userID int64) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	l, exists := ls.locks[commentID]
+	if !exists || l.userID != userID {
+		return false
+	}
+	delete(ls.locks, commentID)
+	return true
+}
+
+// lockedComment 解析 :commentId 并确认评论存在。
+func (s *server) lockedComment(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	commentID, err := strconv.ParseInt(param(r, "commentId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid comment id")
+		return 0, false
+	}
+	s.store.mu.Lock()
+	_, exists := s.store.comments[commentID]
+	s.store.mu.Unlock()
+	if !exists {
+		writeError(w, http.StatusNotFound, "comment not found")
+		return 0, false
+	}
+	return commentID, true
+}
+
+// handleLockComment 获取评论的编辑锁，已被他人持有返回 423 Locked。
+func (s *server) handleLockComment(w http.ResponseWriter, r *http.Request, u *User) {
+	commentID, ok := s.lockedComment(w, r)
+	if !ok {
+		return
+	}
+	acquired, holder, expires := s.commentLocks.acquire(commentID, u.ID)
+	if !acquired {
+		writeJSON(w, http.StatusLocked, map[string]any{
+			"error":     "comment is being edited by another user",
+			"locked_by": holder,
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"comment_id": commentID,
+		"expires_at": expires,
+	})
+}
+
+// handleUnlockComment 释放自己持有的编辑锁。
+func (s *server) handleUnlockComment(w http.ResponseWriter, r *http.Request, u *User) {
+	commentID, ok := s.lockedComment(w, r)
+	if !ok {
+		return
+	}
+	if !s.commentLocks.release(commentID, u.ID) {
+		writeError(w, http.StatusForbidden, "you do not hold this lock")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"comment_id": commentID, "released": true})
+}
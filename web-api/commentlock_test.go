@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func makeComment(t *testing.T, srv *server, token string, taskID int64, content string) Comment {
+	t.Helper()
+	rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", taskID), token,
+		map[string]string{"content": content})
+	if rec.Code != 201 {
+		t.Fatalf("create comment: status = %d", rec.Code)
+	}
+	return decodeBody[Comment](t, rec)
+}
+
+func TestCommentLockAcquireAndReject(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, _ := registerAndLogin(t, srv, "alice")
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+	task := makeTasks(t, srv, aliceToken, "t")[0]
+	c := makeComment(t, srv, aliceToken, task.ID, "hello")
+
+	rec := doJSON(srv, "POST", fmt.Sprintf("/comments/%d/lock", c.ID), aliceToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("acquire: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	// 他人被拒，带上持有者信息
+	rec = doJSON(srv, "POST", fmt.Sprintf("/comments/%d/lock", c.ID), bobToken, nil)
+	if rec.Code != 423 {
+		t.Fatalf("contended acquire: status = %d, want 423", rec.Code)
+	}
+	// 自己重复上锁等于续期
+	rec = doJSON(srv, "POST", fmt.Sprintf("/comments/%d/lock", c.ID), aliceToken, nil)
+	if rec.Code != 200 {
+		t.Errorf("re-acquire own lock: status = %d", rec.Code)
+	}
+}
+
+func TestCommentLockExpiry(t *testing.T) {
+	srv := newTestServer()
+	srv.commentLocks = newLockStore(time.Minute)
+	aliceToken, _ := registerAndLogin(t, srv, "alice")
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+	task := makeTasks(t, srv, aliceToken, "t")[0]
+	c := makeComment(t, srv, aliceToken, task.ID, "hello")
+
+	doJSON(srv, "POST", fmt.Sprintf("/comments/%d/lock", c.ID), aliceToken, nil)
+
+	// 把锁改成已过期：bob 可以拿到
+	srv.commentLocks.mu.Lock()
+	l := srv.commentLocks.locks[c.ID]
+	l.expires = time.Now().Add(-time.Second)
+	srv.commentLocks.locks[c.ID] = l
+	srv.commentLocks.mu.Unlock()
+
+	rec := doJSON(srv, "POST", fmt.Sprintf("/comments/%d/lock", c.ID), bobToken, nil)
+	if rec.Code != 200 {
+		t.Errorf("acquire after expiry: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestCommentLockRelease(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, _ := registerAndLogin(t, srv, "alice")
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+	task := makeTasks(t, srv, aliceToken, "t")[0]
+	c := makeComment(t, srv, aliceToken, task.ID, "hello")
+
+	doJSON(srv, "POST", fmt.Sprintf("/comments/%d/lock", c.ID), aliceToken, nil)
+
+	// 别人释放不了
+	rec := doJSON(srv, "DELETE", fmt.Sprintf("/comments/%d/lock", c.ID), bobToken, nil)
+	if rec.Code != 403 {
+		t.Errorf("foreign release: status = %d, want 403", rec.Code)
+	}
+	// 自己释放后 bob 能拿到
+	rec = doJSON(srv, "DELETE", fmt.Sprintf("/comments/%d/lock", c.ID), aliceToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("release: status = %d", rec.Code)
+	}
+	rec = doJSON(srv, "POST", fmt.Sprintf("/comments/%d/lock", c.ID), bobToken, nil)
+	if rec.Code != 200 {
+		t.Errorf("acquire after release: status = %d", rec.Code)
+	}
+}
+
+func TestCommentLockMissingComment(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "POST", "/comments/9999/lock", token, nil)
+	if rec.Code != 404 {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ownTask 解析 :id 并校验任务属于当前用户。
+func (s *server) ownTask(w http.ResponseWriter, r *http.Request, u *User) (*Task, bool) {
+	id, err := strconv.ParseInt(param(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid task id")
+		return nil, false
+	}
+	t := s.store.getTask(id)
+	if t == nil || t.UserID != u.ID {
+		writeErrCode(w, ErrTaskNotFound)
+		return nil, false
+	}
+	return t, true
+}
+
+func (s *server) handleCreateComment(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	var req struct {
+		Content string `json:"content"`
+		QuoteID *int64 `json:"quote_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Content == "" {
+		writeError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+	if len([]rune(req.Content)) > maxCommentRunes {
+		writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("comment must be at most %d characters", maxCommentRunes))
+		return
+	}
+	if s.recentCommentCount(u.ID) >= maxCommentsPerMinute {
+		writeError(w, http.StatusTooManyRequests,
+			fmt.Sprintf("at most %d comments per minute", maxCommentsPerMinute))
+		return
+	}
+	// 引用回复：被引用的评论必须在同一个任务下
+	if req.QuoteID != nil {
+		s.store.mu.Lock()
+		quoted := s.store.comments[*req.QuoteID]
+		s.store.mu.Unlock()
+		if quoted == nil || quoted.TaskID != t.ID {
+			writeError(w, http.StatusNotFound, "quoted comment not found")
+			return
+		}
+	}
+	content, hit := s.sensitive.Filter(req.Content)
+	if hit && s.sensitive.mode == FilterModeReject {
+		writeError(w, http.StatusBadRequest, "comment contains sensitive words")
+		return
+	}
+	c := s.store.createComment(t.ID, u.ID, content)
+	if req.QuoteID != nil {
+		s.store.mu.Lock()
+		c.QuoteID = req.QuoteID
+		s.store.mu.Unlock()
+		c.Quoted = s.quotePreview(*req.QuoteID)
+	}
+	writeJSON(w, http.StatusCreated, c)
+}
+
+// handleMarkCommentsRead 标记某任务的评论已读到当前时刻。
+func (s *server) handleMarkCommentsRead(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	s.store.markCommentsRead(u.ID, t.ID)
+	writeJSON(w, http.StatusOK, map[string]any{"task_id": t.ID, "unread_comments": 0})
+}
+
+// handleLikeComment 给评论点赞，幂等：同一用户重复点赞不累计。
+// 点赞和关注一样不要求是任务所有者，热门排序靠的就是别人的赞。
+func (s *server) handleLikeComment(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.watchedTask(w, r)
+	if !ok {
+		return
+	}
+	commentID, err := strconv.ParseInt(param(r, "commentId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid comment id")
+		return
+	}
+	found := false
+	for _, c := range s.store.listCommentsOfTask(t.ID) {
+		if c.ID == commentID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "comment not found")
+		return
+	}
+	likes := s.store.likeComment(commentID, u.ID)
+	writeJSON(w, http.StatusOK, map[string]any{"comment_id": commentID, "likes": likes})
+}
+
+// 评论防刷：长度上限（按字符数，汉字算一个）和每分钟条数上限。
+const (
+	maxCommentRunes      = 2000
+	maxCommentsPerMinute = 5
+)
+
+// recentCommentCount 统计用户最近一分钟发了几条评论。
+func (s *server) recentCommentCount(userID int64) int {
+	cutoff := time.Now().Add(-time.Minute)
+	n := 0
+	for _, c := range s.store.listCommentsOf(userID) {
+		if c.CreatedAt.After(cutoff) {
+			n++
+		}
+	}
+	return n
+}
+
+const defaultCommentPageSize = 20
+
+// handleListComments 评论的游标分页：cursor 是上一页最后一条的 id，
+// order=asc|desc 控制方向（默认 desc，新评论在前），
+// 游标的比较方向跟着 order 走。
+func (s *server) handleListComments(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	cursor, _ := strconv.ParseInt(r.URL.Query().Get("cursor"), 10, 64)
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = defaultCommentPageSize
+	}
+	order := r.URL.Query().Get("order")
+	if order == "" {
+		order = "desc"
+	}
+	if order != "asc" && order != "desc" {
+		writeError(w, http.StatusBadRequest, "order must be asc or desc")
+		return
+	}
+
+	all := s.store.listCommentsOfTask(t.ID) // id 升序
+	for _, c := range all {
+		c.Likes = s.store.likeCount(c.ID)
+		if c.QuoteID != nil {
+			c.Quoted = s.quotePreview(*c.QuoteID)
+		}
+	}
+	if r.URL.Query().Get("sort") == "top" {
+		// 热门排序：点赞多的在前，同赞数按 id 升序保持稳定。
+		// 点赞数随时会变，游标没有稳定含义，这里忽略 cursor 参数。
+		sort.SliceStable(all, func(i, j int) bool { return all[i].Likes > all[j].Likes })
+		cursor = 0
+	} else if order == "desc" {
+		for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+			all[i], all[j] = all[j], all[i]
+		}
+	}
+	// 多取一条：取到了说明后面还有数据，客户端不用再发一次试探请求。
+	items := []*Comment{}
+	for _, c := range all {
+		// cursor=0 视为未设置；asc 取 id 更大的，desc 取 id 更小的
+		if cursor != 0 {
+			if order == "asc" && c.ID <= cursor {
+				continue
+			}
+			if order == "desc" && c.ID >= cursor {
+				continue
+			}
+		}
+		if len(items) == limit+1 {
+			break
+		}
+		items = append(items, c)
+	}
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	var nextCursor *int64
+	if hasMore && r.URL.Query().Get("sort") != "top" {
+		id := items[len(items)-1].ID
+		nextCursor = &id
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items":       items,
+		"total":       len(all),
+		"next_cursor": nextCursor,
+		"has_more":    hasMore,
+	})
+}
+
+// defaultCommentEditWindow 评论发出后允许编辑的时长，超过就当历史记录锁死。
+const defaultCommentEditWindow = 5 * time.Minute
+
+// handleEditComment 编辑自己的评论，超出编辑窗口返回 403。
+func (s *server) handleEditComment(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	commentID, err := strconv.ParseInt(param(r, "commentId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid comment id")
+		return
+	}
+	var req struct {
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Content == "" {
+		writeError(w, http.StatusBadRequest, "content is required")
+		return
+	}
+	if len([]rune(req.Content)) > maxCommentRunes {
+		writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("comment must be at most %d characters", maxCommentRunes))
+		return
+	}
+	content, hit := s.sensitive.Filter(req.Content)
+	if hit && s.sensitive.mode == FilterModeReject {
+		writeError(w, http.StatusBadRequest, "comment contains sensitive words")
+		return
+	}
+
+	s.store.mu.Lock()
+	c := s.store.comments[commentID]
+	if c == nil || c.TaskID != t.ID {
+		s.store.mu.Unlock()
+		writeError(w, http.StatusNotFound, "comment not found")
+		return
+	}
+	if c.UserID != u.ID {
+		s.store.mu.Unlock()
+		writeError(w, http.StatusForbidden, "can only edit your own comments")
+		return
+	}
+	if time.Since(c.CreatedAt) > s.commentEditWindow {
+		s.store.mu.Unlock()
+		writeError(w, http.StatusForbidden, "comment is too old to edit")
+		return
+	}
+	c.Content = content
+	now := time.Now()
+	c.EditedAt = &now
+	s.store.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, c)
+}
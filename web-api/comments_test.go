@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type commentPage struct {
+	Items      []Comment `json:"items"`
+	Total      int       `json:"total"`
+	NextCursor *int64    `json:"next_cursor"`
+	HasMore    bool      `json:"has_more"`
+}
+
+func TestCommentReadState(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	unread := func() float64 {
+		rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", task.ID), token, nil)
+		detail := decodeBody[map[string]any](t, rec)
+		n, _ := detail["unread_comments"].(float64)
+		return n
+	}
+
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]string{"content": "first"})
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]string{"content": "second"})
+	if got := unread(); got != 2 {
+		t.Errorf("unread = %v, want 2 before mark-read", got)
+	}
+
+	rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/mark-read", task.ID), token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("mark-read: status = %d", rec.Code)
+	}
+	if got := unread(); got != 0 {
+		t.Errorf("unread = %v, want 0 after mark-read", got)
+	}
+
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]string{"content": "third"})
+	if got := unread(); got != 1 {
+		t.Errorf("unread = %v, want 1 for new comment after mark-read", got)
+	}
+}
+
+func TestCommentPaginationAndCount(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	for i := 0; i < 5; i++ {
+		rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+			map[string]string{"content": fmt.Sprintf("comment %d", i)})
+		if rec.Code != 201 {
+			t.Fatalf("create comment: %d", rec.Code)
+		}
+	}
+
+	// 详情里的总数不受分页影响
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", task.ID), token, nil)
+	detail := decodeBody[map[string]any](t, rec)
+	if got := detail["comments_count"]; got != float64(5) {
+		t.Errorf("comments_count = %v, want 5", got)
+	}
+
+	// 游标翻页：不重不漏
+	seen := map[int64]bool{}
+	cursor := int64(0)
+	pages := 0
+	for {
+		rec := doJSON(srv, "GET",
+			fmt.Sprintf("/tasks/%d/comments?limit=2&cursor=%d", task.ID, cursor), token, nil)
+		page := decodeBody[commentPage](t, rec)
+		if page.Total != 5 {
+			t.Errorf("total = %d, want 5", page.Total)
+		}
+		for _, c := range page.Items {
+			if seen[c.ID] {
+				t.Fatalf("duplicate comment %d across pages", c.ID)
+			}
+			seen[c.ID] = true
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		cursor = *page.NextCursor
+		if pages++; pages > 10 {
+			t.Fatal("pagination did not terminate")
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("saw %d comments, want 5", len(seen))
+	}
+}
+
+func TestCommentHasMore(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	// 空结果
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments?limit=3", task.ID), token, nil)
+	if page := decodeBody[commentPage](t, rec); page.HasMore {
+		t.Error("empty: has_more = true, want false")
+	}
+
+	for i := 0; i < 3; i++ {
+		doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+			map[string]string{"content": fmt.Sprintf("c%d", i)})
+	}
+
+	// 正好一页：没有下一页
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments?limit=3", task.ID), token, nil)
+	page := decodeBody[commentPage](t, rec)
+	if page.HasMore || page.NextCursor != nil {
+		t.Errorf("exact page: has_more = %v, next_cursor = %v", page.HasMore, page.NextCursor)
+	}
+
+	// 还有更多
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments?limit=2", task.ID), token, nil)
+	page = decodeBody[commentPage](t, rec)
+	if !page.HasMore || len(page.Items) != 2 {
+		t.Errorf("partial page: has_more = %v, items = %d", page.HasMore, len(page.Items))
+	}
+}
+
+func TestCommentOrder(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	for i := 0; i < 4; i++ {
+		doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+			map[string]string{"content": fmt.Sprintf("c%d", i)})
+	}
+
+	// 默认 desc：最新的在前
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments", task.ID), token, nil)
+	page := decodeBody[commentPage](t, rec)
+	if len(page.Items) != 4 || page.Items[0].Content != "c3" || page.Items[3].Content != "c0" {
+		t.Errorf("desc items = %v", page.Items)
+	}
+
+	// asc：按创建顺序
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments?order=asc", task.ID), token, nil)
+	page = decodeBody[commentPage](t, rec)
+	if len(page.Items) != 4 || page.Items[0].Content != "c0" {
+		t.Errorf("asc items = %v", page.Items)
+	}
+
+	// desc 下游标方向也反过来：翻页不重不漏
+	seen := []string{}
+	cursor := int64(0)
+	for {
+		url := fmt.Sprintf("/tasks/%d/comments?limit=3&order=desc", task.ID)
+		if cursor != 0 {
+			url += fmt.Sprintf("&cursor=%d", cursor)
+		}
+		page := decodeBody[commentPage](t, doJSON(srv, "GET", url, token, nil))
+		for _, c := range page.Items {
+			seen = append(seen, c.Content)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		cursor = *page.NextCursor
+	}
+	want := []string{"c3", "c2", "c1", "c0"}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Errorf("desc pages = %v, want %v", seen, want)
+	}
+
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments?order=sideways", task.ID), token, nil)
+	if rec.Code != 400 {
+		t.Errorf("bad order: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestCommentTopSort(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, _ := registerAndLogin(t, srv, "alice")
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+	task := makeTasks(t, srv, aliceToken, "t")[0]
+
+	ids := []int64{}
+	for i := 0; i < 3; i++ {
+		rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), aliceToken,
+			map[string]string{"content": fmt.Sprintf("c%d", i)})
+		ids = append(ids, decodeBody[Comment](t, rec).ID)
+	}
+
+	// c1 两个赞（重复点赞不累计），c2 一个赞，c0 没有
+	like := func(token string, commentID int64) *int {
+		rec := doJSON(srv, "POST",
+			fmt.Sprintf("/tasks/%d/comments/%d/like", task.ID, commentID), token, nil)
+		if rec.Code != 200 {
+			t.Fatalf("like: status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+		n := int(decodeBody[map[string]float64](t, rec)["likes"])
+		return &n
+	}
+	like(aliceToken, ids[1])
+	if n := like(aliceToken, ids[1]); *n != 1 {
+		t.Errorf("duplicate like count = %d, want 1", *n)
+	}
+	like(bobToken, ids[1])
+	like(aliceToken, ids[2])
+
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments?sort=top", task.ID), aliceToken, nil)
+	page := decodeBody[commentPage](t, rec)
+	if len(page.Items) != 3 {
+		t.Fatalf("items = %d", len(page.Items))
+	}
+	if page.Items[0].Content != "c1" || page.Items[0].Likes != 2 {
+		t.Errorf("top = %+v", page.Items[0])
+	}
+	if page.Items[1].Content != "c2" || page.Items[2].Content != "c0" {
+		t.Errorf("order = %v, %v", page.Items[1].Content, page.Items[2].Content)
+	}
+
+	// 不带 sort=top 仍按时间（id desc）排
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments", task.ID), aliceToken, nil)
+	page = decodeBody[commentPage](t, rec)
+	if page.Items[0].Content != "c2" {
+		t.Errorf("default order first = %v", page.Items[0].Content)
+	}
+}
+
+func TestCommentLengthLimit(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	path := fmt.Sprintf("/tasks/%d/comments", task.ID)
+
+	// 空内容
+	rec := doJSON(srv, "POST", path, token, map[string]string{"content": ""})
+	if rec.Code != 400 {
+		t.Errorf("empty: status = %d, want 400", rec.Code)
+	}
+	// 超长（2001 个字符）
+	long := strings.Repeat("字", maxCommentRunes+1)
+	rec = doJSON(srv, "POST", path, token, map[string]string{"content": long})
+	if rec.Code != 400 {
+		t.Errorf("too long: status = %d, want 400", rec.Code)
+	}
+	// 正好到上限可以发
+	rec = doJSON(srv, "POST", path, token,
+		map[string]string{"content": strings.Repeat("字", maxCommentRunes)})
+	if rec.Code != 201 {
+		t.Errorf("at limit: status = %d, want 201", rec.Code)
+	}
+}
+
+func TestCommentRateLimit(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	path := fmt.Sprintf("/tasks/%d/comments", task.ID)
+
+	for i := 0; i < maxCommentsPerMinute; i++ {
+		rec := doJSON(srv, "POST", path, token, map[string]string{"content": "spam?"})
+		if rec.Code != 201 {
+			t.Fatalf("comment %d: status = %d", i, rec.Code)
+		}
+	}
+	rec := doJSON(srv, "POST", path, token, map[string]string{"content": "one more"})
+	if rec.Code != 429 {
+		t.Fatalf("over rate: status = %d, want 429", rec.Code)
+	}
+
+	// 一分钟后窗口滑走，可以继续发
+	srv.store.mu.Lock()
+	for _, c := range srv.store.comments {
+		c.CreatedAt = c.CreatedAt.Add(-2 * time.Minute)
+	}
+	srv.store.mu.Unlock()
+	rec = doJSON(srv, "POST", path, token, map[string]string{"content": "later"})
+	if rec.Code != 201 {
+		t.Errorf("after window: status = %d, want 201", rec.Code)
+	}
+}
+
+func TestCommentEditWithinWindow(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]string{"content": "第一版"})
+	c := decodeBody[Comment](t, rec)
+
+	rec = doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/comments/%d", task.ID, c.ID), token,
+		map[string]string{"content": "改过了"})
+	if rec.Code != 200 {
+		t.Fatalf("edit: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	edited := decodeBody[Comment](t, rec)
+	if edited.Content != "改过了" || edited.EditedAt == nil {
+		t.Errorf("edited = %+v", edited)
+	}
+}
+
+func TestCommentEditExpiredWindow(t *testing.T) {
+	srv := newTestServer()
+	srv.commentEditWindow = time.Minute
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	c := decodeBody[Comment](t, doJSON(srv, "POST",
+		fmt.Sprintf("/tasks/%d/comments", task.ID), token, map[string]string{"content": "旧评论"}))
+
+	// 发出时间挪到窗口之外
+	srv.store.mu.Lock()
+	srv.store.comments[c.ID].CreatedAt = time.Now().Add(-2 * time.Minute)
+	srv.store.mu.Unlock()
+
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/comments/%d", task.ID, c.ID), token,
+		map[string]string{"content": "篡改"})
+	if rec.Code != 403 {
+		t.Fatalf("expired edit: status = %d, want 403", rec.Code)
+	}
+
+	// 贴着窗口边界（还差几秒过期）仍可编辑
+	srv.store.mu.Lock()
+	srv.store.comments[c.ID].CreatedAt = time.Now().Add(-time.Minute + 5*time.Second)
+	srv.store.mu.Unlock()
+	rec = doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/comments/%d", task.ID, c.ID), token,
+		map[string]string{"content": "赶上末班车"})
+	if rec.Code != 200 {
+		t.Errorf("boundary edit: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestCommentEditOnlyOwn(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, aliceToken, "t")[0]
+	c := decodeBody[Comment](t, doJSON(srv, "POST",
+		fmt.Sprintf("/tasks/%d/comments", task.ID), aliceToken, map[string]string{"content": "hi"}))
+
+	// 任务不属于 bob，直接 404
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/comments/%d", task.ID, c.ID), bobToken,
+		map[string]string{"content": "hack"})
+	if rec.Code != 404 {
+		t.Errorf("foreign task edit: status = %d, want 404", rec.Code)
+	}
+}
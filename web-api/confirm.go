@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// confirmStore 管理员危险操作的二次确认令牌，带 TTL。
+// 第一次请求拿到 token，短时间内带 token 重发才真正执行。
+type confirmStore struct {
+	mu    sync.Mutex
+	items map[string]confirmItem
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+type confirmItem struct {
+	action    string // 绑定具体操作，防止 token 挪用
+	expiresAt time.Time
+}
+
+func newConfirmStore(ttl time.Duration) *confirmStore {
+	return &confirmStore{
+		items: make(map[string]confirmItem),
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+func (cs *confirmStore) issue(action string) string {
+	token := randomToken()
+	cs.mu.Lock()
+	cs.items[token] = confirmItem{action: action, expiresAt: cs.now().Add(cs.ttl)}
+	cs.mu.Unlock()
+	return token
+}
+
+// consume 校验 token 是否匹配操作且未过期，用过即作废。
+func (cs *confirmStore) consume(token, action string) bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	item, ok := cs.items[token]
+	if !ok {
+		return false
+	}
+	delete(cs.items, token)
+	return item.action == action && cs.now().Before(item.expiresAt)
+}
+
+// confirmed 实现二次确认流程。
+// 未带 X-Confirm-Token 时签发 token 并返回 202，调用方应直接 return；
+// 带了有效 token 返回 true 放行；token 无效/过期返回 401。
+func (s *server) confirmed(w http.ResponseWriter, r *http.Request) bool {
+	action := r.Method + " " + r.URL.Path
+	token := r.Header.Get("X-Confirm-Token")
+	if token == "" {
+		writeJSON(w, http.StatusAccepted, map[string]any{
+			"message":       "confirmation required, repeat the request with X-Confirm-Token",
+			"confirm_token": s.confirms.issue(action),
+			"expires_in":    int(s.confirms.ttl.Seconds()),
+		})
+		return false
+	}
+	if !s.confirms.consume(token, action) {
+		writeError(w, http.StatusUnauthorized, "invalid or expired confirm token")
+		return false
+	}
+	return true
+}
+
+// handleAdminDeleteUser 删除用户（危险操作，需二次确认）。
+func (s *server) handleAdminDeleteUser(w http.ResponseWriter, r *http.Request, admin *User) {
+	id, err := strconv.ParseInt(param(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	if s.store.getUser(id) == nil {
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if !s.confirmed(w, r) {
+		return
+	}
+	s.store.deleteUser(id)
+	w.WriteHeader(http.StatusNoContent)
+}
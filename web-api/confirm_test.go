@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAdminDeleteUserNeedsConfirmation(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	_, victimID := registerAndLogin(t, srv, "victim")
+	path := fmt.Sprintf("/admin/users/%d", victimID)
+
+	// 第一步：没有确认 token，返回 202 和 token
+	rec := doJSON(srv, "DELETE", path, adminToken, nil)
+	if rec.Code != 202 {
+		t.Fatalf("first request: status = %d, want 202", rec.Code)
+	}
+	confirmToken := decodeBody[map[string]any](t, rec)["confirm_token"].(string)
+	if confirmToken == "" {
+		t.Fatal("empty confirm token")
+	}
+	if srv.store.getUser(victimID) == nil {
+		t.Fatal("user deleted before confirmation")
+	}
+
+	// 第二步：带 token 真正执行
+	req := httptest.NewRequest("DELETE", path, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	req.Header.Set("X-Confirm-Token", confirmToken)
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != 204 {
+		t.Fatalf("confirmed request: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if srv.store.getUser(victimID) != nil {
+		t.Error("user should be deleted")
+	}
+}
+
+func TestConfirmTokenExpired(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	_, victimID := registerAndLogin(t, srv, "victim")
+	path := fmt.Sprintf("/admin/users/%d", victimID)
+
+	base := time.Now()
+	srv.confirms.now = func() time.Time { return base }
+
+	rec := doJSON(srv, "DELETE", path, adminToken, nil)
+	token := decodeBody[map[string]any](t, rec)["confirm_token"].(string)
+
+	// 时间快进到过期之后
+	srv.confirms.now = func() time.Time { return base.Add(10 * time.Minute) }
+
+	req := httptest.NewRequest("DELETE", path, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	req.Header.Set("X-Confirm-Token", token)
+	rec2 := httptest.NewRecorder()
+	srv.ServeHTTP(rec2, req)
+	if rec2.Code != 401 {
+		t.Fatalf("expired token: status = %d, want 401", rec2.Code)
+	}
+	if srv.store.getUser(victimID) == nil {
+		t.Error("user should not be deleted with expired token")
+	}
+}
+
+func TestConfirmTokenBoundToAction(t *testing.T) {
+	cs := newConfirmStore(time.Minute)
+	token := cs.issue("DELETE /admin/users/1")
+	if cs.consume(token, "DELETE /admin/users/2") {
+		t.Error("token must not be valid for a different action")
+	}
+}
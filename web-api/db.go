@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// 数据库慢启动重试参数：容器编排里 DB 往往比应用起得慢，
+// 直接 Fatal 退出会造成无意义的重启循环。
+const (
+	dbRetryAttempts  = 5
+	dbRetryBaseDelay = 500 * time.Millisecond
+)
+
+// connectWithRetry 带指数退避的连接重试：
+// 每次失败后等待 base、2*base、4*base……最多试 attempts 次，
+// 全部失败返回最后一次的错误。sleep 可注入便于测试。
+func connectWithRetry(connect func() error, attempts int, base time.Duration, sleep func(time.Duration)) error {
+	var err error
+	delay := base
+	for i := 0; i < attempts; i++ {
+		if err = connect(); err == nil {
+			return nil
+		}
+		if i < attempts-1 {
+			log.Printf("db not ready (attempt %d/%d): %v, retrying in %v", i+1, attempts, err, delay)
+			sleep(delay)
+			delay *= 2
+		}
+	}
+	return fmt.Errorf("database unreachable after %d attempts: %w", attempts, err)
+}
+
+// initDB 等待数据库就绪。目前存储是内存实现，connect 恒成功，
+// 换成真实数据库时只需把连接函数传进来。
+func initDB(connect func() error) error {
+	return connectWithRetry(connect, dbRetryAttempts, dbRetryBaseDelay, time.Sleep)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConnectWithRetryEventualSuccess(t *testing.T) {
+	calls := 0
+	connect := func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	var delays []time.Duration
+	sleep := func(d time.Duration) { delays = append(delays, d) }
+
+	if err := connectWithRetry(connect, 5, time.Second, sleep); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	// 指数退避：1s、2s
+	if len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Errorf("delays = %v", delays)
+	}
+}
+
+func TestConnectWithRetryAllFail(t *testing.T) {
+	calls := 0
+	connect := func() error {
+		calls++
+		return errors.New("connection refused")
+	}
+	err := connectWithRetry(connect, 3, time.Millisecond, func(time.Duration) {})
+	if err == nil {
+		t.Fatal("want error after all attempts fail")
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestConnectWithRetryImmediateSuccess(t *testing.T) {
+	slept := false
+	err := connectWithRetry(func() error { return nil }, 3, time.Second,
+		func(time.Duration) { slept = true })
+	if err != nil || slept {
+		t.Errorf("err = %v, slept = %v", err, slept)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"net/http"
+	"runtime"
+)
+
+// handleDebugRuntime 运行时健康诊断，只开放给管理员。
+// 把 runtime 包里最常看的几个指标汇总成一个 JSON。
+func (s *server) handleDebugRuntime(w http.ResponseWriter, r *http.Request, u *User) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"goroutines":  runtime.NumGoroutine(),
+		"gomaxprocs":  runtime.GOMAXPROCS(0),
+		"go_version":  runtime.Version(),
+		"num_gc":      m.NumGC,
+		"alloc_bytes": m.Alloc,
+		"total_alloc": m.TotalAlloc,
+		"sys_bytes":   m.Sys,
+		"heap_object": m.HeapObjects,
+	})
+}
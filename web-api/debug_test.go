@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestDebugRuntimeAdminOnly(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin") // 第一个用户是管理员
+	userToken, _ := registerAndLogin(t, srv, "normal")
+
+	rec := doJSON(srv, "GET", "/debug/runtime", userToken, nil)
+	if rec.Code != 403 {
+		t.Fatalf("non-admin: status = %d, want 403", rec.Code)
+	}
+
+	rec = doJSON(srv, "GET", "/debug/runtime", adminToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("admin: status = %d", rec.Code)
+	}
+	stats := decodeBody[map[string]any](t, rec)
+	for _, key := range []string{"goroutines", "gomaxprocs", "go_version", "num_gc", "alloc_bytes", "sys_bytes"} {
+		if _, ok := stats[key]; !ok {
+			t.Errorf("missing field %q in %v", key, stats)
+		}
+	}
+	if g, _ := stats["goroutines"].(float64); g < 1 {
+		t.Errorf("goroutines = %v, want > 0", stats["goroutines"])
+	}
+	if g, _ := stats["gomaxprocs"].(float64); g < 1 {
+		t.Errorf("gomaxprocs = %v, want >= 1", stats["gomaxprocs"])
+	}
+}
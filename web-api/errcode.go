@@ -0,0 +1,61 @@
+package main
+
+import "net/http"
+
+// errCode 全局业务错误码：前三位是 HTTP 状态，后两位是业务序号，
+// 客户端可以拿 code 做国际化，message 只是默认文案。
+type errCode int
+
+const (
+	ErrBadRequest   errCode = 40001
+	ErrUnauthorized errCode = 40101
+	ErrTokenExpired errCode = 40102
+	ErrForbidden    errCode = 40301
+	ErrTaskNotFound errCode = 40401
+	ErrUserNotFound errCode = 40402
+	ErrTagNotFound  errCode = 40403
+	ErrConflict     errCode = 40901
+	ErrTooMany      errCode = 42901
+	ErrInternal     errCode = 50001
+)
+
+// errCodeTable 错误码 -> HTTP 状态和默认消息的映射。
+var errCodeTable = map[errCode]struct {
+	status  int
+	message string
+}{
+	ErrBadRequest:   {http.StatusBadRequest, "invalid request"},
+	ErrUnauthorized: {http.StatusUnauthorized, "unauthorized"},
+	ErrTokenExpired: {http.StatusUnauthorized, "token expired"},
+	ErrForbidden:    {http.StatusForbidden, "forbidden"},
+	ErrTaskNotFound: {http.StatusNotFound, "task not found"},
+	ErrUserNotFound: {http.StatusNotFound, "user not found"},
+	ErrTagNotFound:  {http.StatusNotFound, "tag not found"},
+	ErrConflict:     {http.StatusConflict, "resource conflict"},
+	ErrTooMany:      {http.StatusTooManyRequests, "too many requests"},
+	ErrInternal:     {http.StatusInternalServerError, "internal error"},
+}
+
+// httpStatus 返回错误码对应的 HTTP 状态，未登记的码按 500 兜底。
+func (c errCode) httpStatus() int {
+	if e, ok := errCodeTable[c]; ok {
+		return e.status
+	}
+	return http.StatusInternalServerError
+}
+
+// message 返回错误码的默认文案。
+func (c errCode) message() string {
+	if e, ok := errCodeTable[c]; ok {
+		return e.message
+	}
+	return "unknown error"
+}
+
+// writeErrCode 按错误码输出统一错误响应，body 里带业务码方便客户端分支。
+func writeErrCode(w http.ResponseWriter, code errCode) {
+	writeJSON(w, code.httpStatus(), map[string]any{
+		"code":  int(code),
+		"error": code.message(),
+	})
+}
@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+func TestErrCodeMapping(t *testing.T) {
+	tests := []struct {
+		code    errCode
+		status  int
+		message string
+	}{
+		{ErrTaskNotFound, 404, "task not found"},
+		{ErrUnauthorized, 401, "unauthorized"},
+		{ErrConflict, 409, "resource conflict"},
+		{ErrInternal, 500, "internal error"},
+	}
+	for _, tt := range tests {
+		if got := tt.code.httpStatus(); got != tt.status {
+			t.Errorf("code %d: status = %d, want %d", tt.code, got, tt.status)
+		}
+		if got := tt.code.message(); got != tt.message {
+			t.Errorf("code %d: message = %q, want %q", tt.code, got, tt.message)
+		}
+	}
+}
+
+func TestErrCodeUnknownFallsBack(t *testing.T) {
+	unknown := errCode(99999)
+	if unknown.httpStatus() != 500 {
+		t.Errorf("unknown code status = %d, want 500", unknown.httpStatus())
+	}
+}
+
+func TestErrCodeInResponseBody(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "GET", "/tasks/9999", token, nil)
+	if rec.Code != 404 {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+	body := decodeBody[map[string]any](t, rec)
+	if body["code"] != float64(ErrTaskNotFound) {
+		t.Errorf("code = %v, want %d", body["code"], ErrTaskNotFound)
+	}
+	if body["error"] != "task not found" {
+		t.Errorf("error = %v", body["error"])
+	}
+}
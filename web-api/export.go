@@ -0,0 +1,25 @@
+package main
+
+import "net/http"
+
+// handleExport GDPR 风格的个人数据导出：
+// 把当前用户的 profile、任务、评论和标签关联打包成一个 JSON。
+// 密码散列因为 json:"-" 不会出现在导出里。
+func (s *server) handleExport(w http.ResponseWriter, r *http.Request, u *User) {
+	tasks := s.store.listTasksOf(u.ID)
+	taskTags := make(map[int64][]int64, len(tasks))
+	for _, t := range tasks {
+		if ids := s.store.tagsOfTask(t.ID); len(ids) > 0 {
+			taskTags[t.ID] = ids
+		}
+	}
+	export := map[string]any{
+		"profile":   u,
+		"tasks":     tasks,
+		"comments":  s.store.listCommentsOf(u.ID),
+		"tags":      s.store.listTagsOf(u.ID),
+		"task_tags": taskTags,
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="export.json"`)
+	writeJSON(w, http.StatusOK, export)
+}
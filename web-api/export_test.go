@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExportContainsOwnDataOnly(t *testing.T) {
+	srv := newTestServer()
+	aToken, _ := registerAndLogin(t, srv, "alice")
+	bToken, _ := registerAndLogin(t, srv, "bob")
+
+	rec := doJSON(srv, "POST", "/tasks", aToken, map[string]any{"title": "alice task"})
+	aTask := decodeBody[Task](t, rec)
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", aTask.ID), aToken, map[string]string{"content": "alice comment"})
+	rec = doJSON(srv, "POST", "/tags", aToken, map[string]string{"name": "alice-tag"})
+	aTag := decodeBody[Tag](t, rec)
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/tags/%d", aTask.ID, aTag.ID), aToken, nil)
+
+	doJSON(srv, "POST", "/tasks", bToken, map[string]any{"title": "bob secret task"})
+
+	rec = doJSON(srv, "GET", "/users/me/export", aToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("export status = %d", rec.Code)
+	}
+	body := rec.Body.String()
+
+	for _, want := range []string{"alice task", "alice comment", "alice-tag", `"profile"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("export missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "bob secret task") {
+		t.Error("export leaks other user's data")
+	}
+	if strings.Contains(strings.ToLower(body), "password") {
+		t.Error("export must not contain password fields")
+	}
+
+	export := decodeBody[map[string]any](t, rec)
+	if _, ok := export["task_tags"]; !ok {
+		t.Error("export missing task_tags associations")
+	}
+}
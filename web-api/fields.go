@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// sparseFields 按 `fields=id,title` 形式的参数裁剪响应，
+// 减小前端不需要全部字段时的传输量。
+// 通过 JSON 编解码转成 map 后挑选字段，对切片逐个元素处理；
+// 不认识的字段名直接忽略，fields 为空时原样返回。
+func sparseFields(v any, fieldsParam string) any {
+	fieldsParam = strings.TrimSpace(fieldsParam)
+	if fieldsParam == "" {
+		return v
+	}
+	wanted := map[string]bool{}
+	for _, f := range strings.Split(fieldsParam, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			wanted[f] = true
+		}
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return v
+	}
+
+	switch d := decoded.(type) {
+	case map[string]any:
+		return pickFields(d, wanted)
+	case []any:
+		out := make([]any, len(d))
+		for i, item := range d {
+			if m, ok := item.(map[string]any); ok {
+				out[i] = pickFields(m, wanted)
+			} else {
+				out[i] = item
+			}
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// toJSONMap 把结构体按其 JSON 形态转成 map，便于在响应里追加字段。
+func toJSONMap(v any) map[string]any {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+func pickFields(m map[string]any, wanted map[string]bool) map[string]any {
+	out := make(map[string]any, len(wanted))
+	for k, v := range m {
+		if wanted[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSparseFieldsOnList(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	makeTasks(t, srv, token, "a", "b")
+
+	rec := doJSON(srv, "GET", "/tasks?fields=id,title", token, nil)
+	items := decodeBody[[]map[string]any](t, rec)
+	if len(items) != 2 {
+		t.Fatalf("items = %v", items)
+	}
+	for _, item := range items {
+		if len(item) != 2 {
+			t.Errorf("item keys = %v, want only id and title", item)
+		}
+		if _, ok := item["id"]; !ok {
+			t.Errorf("missing id: %v", item)
+		}
+		if _, ok := item["title"]; !ok {
+			t.Errorf("missing title: %v", item)
+		}
+	}
+}
+
+func TestSparseFieldsIgnoresUnknown(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "a")[0]
+
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d?fields=id,nosuchfield", task.ID), token, nil)
+	item := decodeBody[map[string]any](t, rec)
+	if len(item) != 1 {
+		t.Errorf("item = %v, want only id", item)
+	}
+}
+
+func TestSparseFieldsDefaultAll(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "a")[0]
+
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", task.ID), token, nil)
+	item := decodeBody[map[string]any](t, rec)
+	for _, key := range []string{"id", "title", "status", "position", "created_at"} {
+		if _, ok := item[key]; !ok {
+			t.Errorf("full response missing %q: %v", key, item)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FilterMode 敏感词的处理方式：替换成 * 或直接拒绝。
+type FilterMode int
+
+const (
+	FilterModeReplace FilterMode = iota
+	FilterModeReject
+)
+
+// SensitiveFilter 基于 trie 的敏感词过滤器。
+// 匹配不区分大小写，并忽略词中间夹杂的分隔符（如 b-a-d 这类变体）。
+type SensitiveFilter struct {
+	root *trieNode
+	mode FilterMode
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	end      bool
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode)}
+}
+
+// NewSensitiveFilter 从词表构建过滤器，词表通常来自配置文件。
+func NewSensitiveFilter(words []string, mode FilterMode) *SensitiveFilter {
+	f := &SensitiveFilter{root: newTrieNode(), mode: mode}
+	for _, w := range words {
+		node := f.root
+		for _, r := range strings.ToLower(w) {
+			next := node.children[r]
+			if next == nil {
+				next = newTrieNode()
+				node.children[r] = next
+			}
+			node = next
+		}
+		node.end = true
+	}
+	return f
+}
+
+// isFillerRune 判断变体里常见的填充字符，匹配时跳过。
+func isFillerRune(r rune) bool {
+	return r == '-' || r == '_' || r == '.' || r == '*' || unicode.IsSpace(r)
+}
+
+// match 从 runes[start] 开始尝试匹配一个敏感词，返回命中的结束下标（开区间）。
+func (f *SensitiveFilter) match(runes []rune, start int) (end int, ok bool) {
+	node := f.root
+	i := start
+	for i < len(runes) {
+		r := unicode.ToLower(runes[i])
+		if next := node.children[r]; next != nil {
+			node = next
+			i++
+			if node.end {
+				return i, true
+			}
+			continue
+		}
+		// 只有已经吃进至少一个字符后才允许跳过填充符
+		if i > start && isFillerRune(runes[i]) {
+			i++
+			continue
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+// Filter 扫描文本，返回处理后的文本和是否命中敏感词。
+// Replace 模式把命中的片段整体替换为等长的 *；Reject 模式原样返回但 hit 为 true。
+func (f *SensitiveFilter) Filter(text string) (filtered string, hit bool) {
+	runes := []rune(text)
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		if end, ok := f.match(runes, i); ok {
+			hit = true
+			if f.mode == FilterModeReplace {
+				b.WriteString(strings.Repeat("*", end-i))
+			} else {
+				b.WriteString(string(runes[i:end]))
+			}
+			i = end
+			continue
+		}
+		b.WriteRune(runes[i])
+		i++
+	}
+	return b.String(), hit
+}
+
+// defaultSensitiveWords 练习用的默认词表，真实项目应从配置加载。
+var defaultSensitiveWords = []string{"fck", "damn", "spamlink", "傻逼"}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSensitiveFilterReplace(t *testing.T) {
+	f := NewSensitiveFilter([]string{"bad", "坏话"}, FilterModeReplace)
+
+	got, hit := f.Filter("this is bad stuff")
+	if !hit || got != "this is *** stuff" {
+		t.Errorf("Filter = %q, hit = %v", got, hit)
+	}
+	got, hit = f.Filter("说坏话的人")
+	if !hit || got != "说**的人" {
+		t.Errorf("Filter = %q, hit = %v", got, hit)
+	}
+}
+
+func TestSensitiveFilterVariants(t *testing.T) {
+	f := NewSensitiveFilter([]string{"bad"}, FilterModeReplace)
+
+	if _, hit := f.Filter("BaD word"); !hit {
+		t.Error("case variant should hit")
+	}
+	if _, hit := f.Filter("b-a-d word"); !hit {
+		t.Error("separator variant should hit")
+	}
+}
+
+func TestSensitiveFilterClean(t *testing.T) {
+	f := NewSensitiveFilter([]string{"bad"}, FilterModeReplace)
+	got, hit := f.Filter("perfectly fine text")
+	if hit || got != "perfectly fine text" {
+		t.Errorf("clean text changed: %q, hit = %v", got, hit)
+	}
+}
+
+func TestCommentSensitiveFilter(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "t"})
+	task := decodeBody[Task](t, rec)
+
+	rec = doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]string{"content": "you damn fool"})
+	if rec.Code != 201 {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	if c := decodeBody[Comment](t, rec); c.Content != "you **** fool" {
+		t.Errorf("content = %q, want masked", c.Content)
+	}
+}
+
+func TestCommentSensitiveReject(t *testing.T) {
+	srv := newTestServer()
+	srv.sensitive = NewSensitiveFilter(defaultSensitiveWords, FilterModeReject)
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "t"})
+	task := decodeBody[Task](t, rec)
+
+	rec = doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]string{"content": "you damn fool"})
+	if rec.Code != 400 {
+		t.Fatalf("status = %d, want 400 in reject mode", rec.Code)
+	}
+}
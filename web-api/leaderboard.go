@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LeaderboardEntry 排行榜上的一行。
+type LeaderboardEntry struct {
+	Rank     int    `json:"rank"`
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Done     int    `json:"done"`
+}
+
+const defaultLeaderboardSize = 10
+
+// completedCounts 统计 since 之后各用户完成的任务数（软删除的不算）。
+func (s *store) completedCounts(since time.Time) map[int64]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[int64]int)
+	for _, t := range s.tasks {
+		if t.DeletedAt != nil || t.CompletedAt == nil || !t.CompletedAt.After(since) {
+			continue
+		}
+		counts[t.UserID]++
+	}
+	return counts
+}
+
+// handleLeaderboard 任务完成排行榜，period=week（近 7 天，默认）或 month（近 30 天）。
+// 完成数相同的用户并列同一名次，下一名次顺延。
+func (s *server) handleLeaderboard(w http.ResponseWriter, r *http.Request, _ *User) {
+	var days int
+	switch r.URL.Query().Get("period") {
+	case "", "week":
+		days = 7
+	case "month":
+		days = 30
+	default:
+		writeError(w, http.StatusBadRequest, "period must be week or month")
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = defaultLeaderboardSize
+	}
+
+	counts := s.store.completedCounts(time.Now().AddDate(0, 0, -days))
+	entries := []LeaderboardEntry{}
+	for uid, n := range counts {
+		u := s.store.getUser(uid)
+		if u == nil {
+			continue
+		}
+		entries = append(entries, LeaderboardEntry{UserID: uid, Username: u.Username, Done: n})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Done != entries[j].Done {
+			return entries[i].Done > entries[j].Done
+		}
+		return entries[i].UserID < entries[j].UserID
+	})
+	// 标准竞赛排名：并列共享名次，1,1,3…
+	for i := range entries {
+		if i > 0 && entries[i].Done == entries[i-1].Done {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": entries, "total": len(entries)})
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type leaderboardPage struct {
+	Items []LeaderboardEntry `json:"items"`
+	Total int                `json:"total"`
+}
+
+func completeTasks(t *testing.T, srv *server, token string, tasks []Task) {
+	t.Helper()
+	for _, task := range tasks {
+		rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token,
+			map[string]any{"status": "done"})
+		if rec.Code != 200 {
+			t.Fatalf("complete task %d: status = %d", task.ID, rec.Code)
+		}
+	}
+}
+
+func TestLeaderboardRanking(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, aliceID := registerAndLogin(t, srv, "alice")
+	bobToken, bobID := registerAndLogin(t, srv, "bob")
+	carolToken, carolID := registerAndLogin(t, srv, "carol")
+
+	completeTasks(t, srv, aliceToken, makeTasks(t, srv, aliceToken, "a1", "a2", "a3"))
+	completeTasks(t, srv, bobToken, makeTasks(t, srv, bobToken, "b1"))
+	completeTasks(t, srv, carolToken, makeTasks(t, srv, carolToken, "c1", "c2", "c3"))
+
+	rec := doJSON(srv, "GET", "/leaderboard", aliceToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("leaderboard: status = %d", rec.Code)
+	}
+	page := decodeBody[leaderboardPage](t, rec)
+	if page.Total != 3 {
+		t.Fatalf("total = %d, want 3", page.Total)
+	}
+	// alice 和 carol 各 3 个并列第 1，bob 第 3
+	if page.Items[0].UserID != aliceID || page.Items[0].Rank != 1 {
+		t.Errorf("items[0] = %+v", page.Items[0])
+	}
+	if page.Items[1].UserID != carolID || page.Items[1].Rank != 1 {
+		t.Errorf("items[1] = %+v, want rank 1 tie", page.Items[1])
+	}
+	if page.Items[2].UserID != bobID || page.Items[2].Rank != 3 {
+		t.Errorf("items[2] = %+v, want rank 3", page.Items[2])
+	}
+}
+
+func TestLeaderboardPeriodFilter(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, aliceID := registerAndLogin(t, srv, "alice")
+	bobToken, bobID := registerAndLogin(t, srv, "bob")
+
+	aliceTasks := makeTasks(t, srv, aliceToken, "old1", "old2")
+	completeTasks(t, srv, aliceToken, aliceTasks)
+	completeTasks(t, srv, bobToken, makeTasks(t, srv, bobToken, "fresh"))
+
+	// alice 的完成时间挪到 10 天前：月榜算数，周榜不算
+	srv.store.mu.Lock()
+	for _, task := range aliceTasks {
+		old := time.Now().AddDate(0, 0, -10)
+		srv.store.tasks[task.ID].CompletedAt = &old
+	}
+	srv.store.mu.Unlock()
+
+	week := decodeBody[leaderboardPage](t, doJSON(srv, "GET", "/leaderboard?period=week", aliceToken, nil))
+	if week.Total != 1 || week.Items[0].UserID != bobID {
+		t.Errorf("week = %+v, want only bob", week)
+	}
+	month := decodeBody[leaderboardPage](t, doJSON(srv, "GET", "/leaderboard?period=month", aliceToken, nil))
+	if month.Total != 2 || month.Items[0].UserID != aliceID || month.Items[0].Done != 2 {
+		t.Errorf("month = %+v, want alice first with 2", month)
+	}
+}
+
+func TestLeaderboardBadPeriod(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "GET", "/leaderboard?period=year", token, nil)
+	if rec.Code != 400 {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// newJTI 生成令牌的唯一标识。
+func newJTI() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// jtiBlacklist 登出令牌的黑名单，按令牌自身的过期时间保留，
+// 过期后条目没有意义，顺手清掉。
+type jtiBlacklist struct {
+	mu      sync.Mutex
+	entries map[string]time.Time // jti -> 令牌过期时间
+}
+
+func newJTIBlacklist() *jtiBlacklist {
+	return &jtiBlacklist{entries: make(map[string]time.Time)}
+}
+
+func (b *jtiBlacklist) add(jti string, expiresAt time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[jti] = expiresAt
+}
+
+func (b *jtiBlacklist) contains(jti string) bool {
+	if jti == "" {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sweep(time.Now())
+	_, ok := b.entries[jti]
+	return ok
+}
+
+// sweep 清理已经自然过期的条目，调用方需持有 b.mu。
+func (b *jtiBlacklist) sweep(now time.Time) {
+	for jti, exp := range b.entries {
+		if now.After(exp) {
+			delete(b.entries, jti)
+		}
+	}
+}
+
+// startBlacklistSweep 定期清理黑名单，main 里启动。
+func (s *server) startBlacklistSweep(interval time.Duration) {
+	go func() {
+		for range time.Tick(interval) {
+			s.revoked.mu.Lock()
+			s.revoked.sweep(time.Now())
+			s.revoked.mu.Unlock()
+		}
+	}()
+}
+
+// handleLogout 吊销当前访问令牌，之后拿它再访问一律 401。
+func (s *server) handleLogout(w http.ResponseWriter, r *http.Request, _ *User) {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	claims, err := parseToken(token)
+	if err != nil {
+		// requireAuth 已经验过，到这基本不会失败
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	s.revoked.add(claims.JTI, time.Unix(claims.ExpiresAt, 0))
+	writeJSON(w, http.StatusOK, map[string]any{"logged_out": true})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogoutRevokesToken(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/auth/logout", token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("logout: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	rec = doJSON(srv, "GET", "/tasks", token, nil)
+	if rec.Code != 401 {
+		t.Errorf("revoked token: status = %d, want 401", rec.Code)
+	}
+	// 重复登出同一令牌也是 401
+	rec = doJSON(srv, "POST", "/auth/logout", token, nil)
+	if rec.Code != 401 {
+		t.Errorf("double logout: status = %d, want 401", rec.Code)
+	}
+}
+
+func TestLogoutDoesNotAffectOtherTokens(t *testing.T) {
+	srv := newTestServer()
+	_ = func() string { token, _ := registerAndLogin(t, srv, "alice"); return token }()
+
+	// 同一用户再登录拿到新令牌（jti 不同）
+	rec := doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123",
+	})
+	first := decodeBody[map[string]any](t, rec)["token"].(string)
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123",
+	})
+	second := decodeBody[map[string]any](t, rec)["token"].(string)
+
+	doJSON(srv, "POST", "/auth/logout", first, nil)
+	if rec := doJSON(srv, "GET", "/tasks", first, nil); rec.Code != 401 {
+		t.Errorf("logged-out token: status = %d, want 401", rec.Code)
+	}
+	if rec := doJSON(srv, "GET", "/tasks", second, nil); rec.Code != 200 {
+		t.Errorf("other token: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestBlacklistSweepsExpiredEntries(t *testing.T) {
+	b := newJTIBlacklist()
+	b.add("gone", time.Now().Add(-time.Minute))
+	b.add("alive", time.Now().Add(time.Minute))
+	if b.contains("gone") {
+		t.Error("expired entry should be swept")
+	}
+	if !b.contains("alive") {
+		t.Error("live entry should stay")
+	}
+}
@@ -0,0 +1,26 @@
+// web-api 是一个任务管理的练习项目：
+// 不依赖 gin/gorm，全部用标准库 + 内存存储实现，
+// 用来演示中间件、路由、认证等 Web 开发的基本套路。
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	addr := os.Getenv("ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	srv := newServer()
+	srv.startPurgeLoop("archive", time.Hour)
+	srv.startBlacklistSweep(time.Hour)
+	log.Printf("web-api listening on %s", addr)
+	if err := http.ListenAndServe(addr, srv); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRateLimit  = 60
+	defaultRateWindow = time.Minute
+)
+
+// rateLimiter 按客户端 IP 做固定窗口限流。
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	clients map[string]*rateWindow
+	now     func() time.Time // 方便测试替换时钟
+}
+
+type rateWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		window:  window,
+		clients: make(map[string]*rateWindow),
+		now:     time.Now,
+	}
+}
+
+// allow 消耗一次配额，返回是否放行、剩余配额和窗口重置时间。
+func (rl *rateLimiter) allow(key string) (ok bool, remaining int, resetAt time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	w := rl.clients[key]
+	if w == nil || !now.Before(w.resetAt) {
+		w = &rateWindow{resetAt: now.Add(rl.window)}
+		rl.clients[key] = w
+	}
+	if w.count >= rl.limit {
+		return false, 0, w.resetAt
+	}
+	w.count++
+	return true, rl.limit - w.count, w.resetAt
+}
+
+// middleware 在响应头里带上配额信息，超限返回 429 和 Retry-After。
+func (rl *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := clientIP(r)
+		ok, remaining, resetAt := rl.allow(key)
+
+		h := w.Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(rl.limit))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+
+		if !ok {
+			retryAfter := int(time.Until(resetAt).Seconds())
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			h.Set("Retry-After", strconv.Itoa(retryAfter))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitHeaders(t *testing.T) {
+	srv := newServer()
+	srv.limiter = newRateLimiter(3, time.Minute)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("X-RateLimit-Limit"); got != "3" {
+		t.Errorf("X-RateLimit-Limit = %q, want 3", got)
+	}
+	if got := rec.Header().Get("X-RateLimit-Remaining"); got != "2" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 2", got)
+	}
+	reset, err := strconv.ParseInt(rec.Header().Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset <= time.Now().Unix() {
+		t.Errorf("X-RateLimit-Reset = %q, want future unix timestamp", rec.Header().Get("X-RateLimit-Reset"))
+	}
+}
+
+func TestRateLimitExceeded(t *testing.T) {
+	srv := newServer()
+	srv.limiter = newRateLimiter(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/ping", nil)
+		req.RemoteAddr = "10.0.0.2:1234"
+		srv.ServeHTTP(rec, req)
+		if rec.Code != 200 {
+			t.Fatalf("request %d status = %d, want 200", i, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != 429 {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 0", got)
+	}
+	if ra, err := strconv.Atoi(rec.Header().Get("Retry-After")); err != nil || ra < 1 {
+		t.Errorf("Retry-After = %q, want >= 1 seconds", rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestRateLimitPerClient(t *testing.T) {
+	rl := newRateLimiter(1, time.Minute)
+	if ok, _, _ := rl.allow("a"); !ok {
+		t.Fatal("first request of client a should pass")
+	}
+	if ok, _, _ := rl.allow("a"); ok {
+		t.Fatal("second request of client a should be limited")
+	}
+	if ok, _, _ := rl.allow("b"); !ok {
+		t.Fatal("client b should have its own quota")
+	}
+}
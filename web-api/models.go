@@ -0,0 +1,80 @@
+package main
+
+import "time"
+
+// User 注册用户。密码散列不出现在任何响应里。
+type User struct {
+	ID           int64      `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	TOTPSecret   string     `json:"-"`
+	TOTPEnabled  bool       `json:"totp_enabled"`
+	IsAdmin      bool       `json:"is_admin"`
+	Bio          string     `json:"bio,omitempty"`
+	ShowEmail    bool       `json:"show_email"` // 公开资料里是否展示邮箱
+	ShowBio      bool       `json:"show_bio"`   // 公开资料里是否展示简介
+	LastLoginAt  *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Project 项目，成员关系相当于 many2many 的 project_members 表。
+type Project struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	OwnerID     int64     `json:"owner_id"`
+	MemberIDs   []int64   `json:"member_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Task 任务，属于某个用户，可选归属项目。
+type Task struct {
+	ID               int64      `json:"id"`
+	UserID           int64      `json:"user_id"`
+	ProjectID        int64      `json:"project_id,omitempty"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Status           string     `json:"status"`                  // todo / doing / done
+	InternalNote     string     `json:"internal_note,omitempty"` // 内部备注，仅管理员可见
+	Priority         int        `json:"priority"`
+	Position         float64    `json:"position"`                    // 看板手动排序位置，浮点中点法插入
+	EstimatedMinutes int        `json:"estimated_minutes,omitempty"` // 估时，0 表示没估
+	DueDate          *time.Time `json:"due_date,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"` // 状态首次变为 done 的时间
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`   // 软删除标记，保留期后物理清理
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Comment 任务下的评论。
+type Comment struct {
+	ID        int64         `json:"id"`
+	TaskID    int64         `json:"task_id"`
+	UserID    int64         `json:"user_id"`
+	Content   string        `json:"content"`
+	Likes     int           `json:"likes"` // 读取时从 likes 表算出，不单独存
+	QuoteID   *int64        `json:"quote_id,omitempty"`
+	Quoted    *QuotePreview `json:"quoted,omitempty"` // 读取时生成，不入库
+	CreatedAt time.Time     `json:"created_at"`
+	EditedAt  *time.Time    `json:"edited_at,omitempty"`
+}
+
+// Tag 用户自己维护的标签，通过 task_tags 关联到任务。
+type Tag struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Name      string    `json:"name"`
+	ParentID  *int64    `json:"parent_id,omitempty"` // 自关联形成标签树
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// isMember 判断用户是否在项目成员列表里。
+func (p *Project) isMember(userID int64) bool {
+	for _, id := range p.MemberIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
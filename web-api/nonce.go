@@ -0,0 +1,74 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// nonceWindow 请求时间戳允许的偏差，超过视为过期请求。
+const nonceWindow = 5 * time.Minute
+
+// nonceStore 记录时间窗口内用过的 nonce，防重放。
+type nonceStore struct {
+	mu   sync.Mutex
+	seen map[string]time.Time // nonce -> 首次使用时间
+	ttl  time.Duration
+
+	now func() time.Time // 测试时可替换
+}
+
+func newNonceStore(ttl time.Duration) *nonceStore {
+	return &nonceStore{
+		seen: make(map[string]time.Time),
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// use 尝试消费一个 nonce：窗口内已用过返回 false。
+// 顺手清掉过期条目，避免 map 无限增长。
+func (n *nonceStore) use(nonce string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := n.now()
+	for k, at := range n.seen {
+		if now.Sub(at) > n.ttl {
+			delete(n.seen, k)
+		}
+	}
+	if _, ok := n.seen[nonce]; ok {
+		return false
+	}
+	n.seen[nonce] = now
+	return true
+}
+
+// requireNonce 给敏感写操作套防重放校验：
+// 请求必须带 X-Nonce 和 X-Timestamp（Unix 秒），
+// 时间戳超出窗口或 nonce 已用过都拒绝。
+func (s *server) requireNonce(h func(http.ResponseWriter, *http.Request, *User)) func(http.ResponseWriter, *http.Request, *User) {
+	return func(w http.ResponseWriter, r *http.Request, u *User) {
+		nonce := r.Header.Get("X-Nonce")
+		if nonce == "" {
+			writeError(w, http.StatusBadRequest, "X-Nonce header is required")
+			return
+		}
+		ts, err := strconv.ParseInt(r.Header.Get("X-Timestamp"), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "X-Timestamp header is required")
+			return
+		}
+		drift := s.nonces.now().Sub(time.Unix(ts, 0))
+		if drift > nonceWindow || drift < -nonceWindow {
+			writeError(w, http.StatusBadRequest, "request timestamp expired")
+			return
+		}
+		if !s.nonces.use(nonce) {
+			writeError(w, http.StatusConflict, "nonce already used")
+			return
+		}
+		h(w, r, u)
+	}
+}
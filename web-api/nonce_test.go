@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// doJSONNonce 同 doJSON，但带上防重放需要的 X-Nonce/X-Timestamp。
+func doJSONNonce(srv *server, method, path, token, nonce string, ts time.Time) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, &bytes.Buffer{})
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("X-Nonce", nonce)
+	req.Header.Set("X-Timestamp", fmt.Sprint(ts.Unix()))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNonceFirstUsePasses(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSONNonce(srv, "POST", "/users/2fa/enable", token, "n-1", time.Now())
+	if rec.Code != 200 {
+		t.Fatalf("first use: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestNonceReplayRejected(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	doJSONNonce(srv, "POST", "/users/2fa/enable", token, "n-1", time.Now())
+	rec := doJSONNonce(srv, "POST", "/users/2fa/enable", token, "n-1", time.Now())
+	if rec.Code != 409 {
+		t.Errorf("replay: status = %d, want 409", rec.Code)
+	}
+}
+
+func TestNonceExpiredTimestampRejected(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSONNonce(srv, "POST", "/users/2fa/enable", token, "n-2",
+		time.Now().Add(-10*time.Minute))
+	if rec.Code != 400 {
+		t.Errorf("expired timestamp: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestNonceMissingHeaders(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/users/2fa/enable", token, nil)
+	if rec.Code != 400 {
+		t.Errorf("missing nonce: status = %d, want 400", rec.Code)
+	}
+}
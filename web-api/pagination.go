@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Pagination 分页元信息，前端靠 TotalPages/HasNext 渲染翻页控件，
+// 不用自己拿 total 除 limit。
+type Pagination struct {
+	Page       int  `json:"page"`
+	Limit      int  `json:"limit"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// NewPagination 计算分页元信息。page/limit 非法时回退到 1/默认值；
+// total 为 0 时 total_pages 也是 0，两个方向都没有更多页。
+func NewPagination(page, limit, total int) Pagination {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	totalPages := (total + limit - 1) / limit
+	return Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1 && total > 0,
+	}
+}
+
+// pageParams 从 query 里取 page/limit，缺省 1/defaultLimit。
+func pageParams(r *http.Request, defaultLimit int) (page, limit int) {
+	page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	return page, limit
+}
+
+// paginate 按 page/limit 切片，越界返回空页。
+func paginate[T any](items []T, page, limit int) []T {
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
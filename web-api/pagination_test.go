@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewPagination(t *testing.T) {
+	p := NewPagination(2, 10, 35)
+	if p.TotalPages != 4 || !p.HasNext || !p.HasPrev {
+		t.Errorf("pagination = %+v", p)
+	}
+	p = NewPagination(4, 10, 35)
+	if p.HasNext || !p.HasPrev {
+		t.Errorf("last page = %+v", p)
+	}
+	p = NewPagination(1, 10, 10)
+	if p.TotalPages != 1 || p.HasNext || p.HasPrev {
+		t.Errorf("exact fit = %+v", p)
+	}
+}
+
+func TestNewPaginationEmpty(t *testing.T) {
+	p := NewPagination(1, 10, 0)
+	if p.TotalPages != 0 || p.HasNext || p.HasPrev {
+		t.Errorf("empty = %+v", p)
+	}
+}
+
+func TestNewPaginationSanitizesInput(t *testing.T) {
+	p := NewPagination(0, -5, 7)
+	if p.Page != 1 || p.Limit != 20 || p.TotalPages != 1 {
+		t.Errorf("sanitized = %+v", p)
+	}
+}
+
+func TestAdminUsersPaged(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	for i := 0; i < 5; i++ {
+		registerAndLogin(t, srv, fmt.Sprintf("user%d", i))
+	}
+
+	rec := doJSON(srv, "GET", "/admin/users?page=2&limit=4", adminToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	body := decodeBody[struct {
+		Items      []User     `json:"items"`
+		Total      int        `json:"total"`
+		Pagination Pagination `json:"pagination"`
+	}](t, rec)
+	if body.Total != 6 || len(body.Items) != 2 {
+		t.Errorf("total = %d, page items = %d, want 6 and 2", body.Total, len(body.Items))
+	}
+	if body.Pagination.TotalPages != 2 || body.Pagination.HasNext || !body.Pagination.HasPrev {
+		t.Errorf("pagination = %+v", body.Pagination)
+	}
+}
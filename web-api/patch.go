@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// patchOp RFC 6902 里的一条操作，这里只支持 replace 和 remove。
+type patchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyTaskPatch 把一组 patch 应用到任务字段的副本上，
+// 任何一条非法就整体报错，不会留下改了一半的任务。
+func applyTaskPatch(t Task, ops []patchOp) (Task, error) {
+	for i, op := range ops {
+		switch op.Op {
+		case "replace":
+			if err := patchReplace(&t, op); err != nil {
+				return t, fmt.Errorf("op %d: %w", i, err)
+			}
+		case "remove":
+			switch op.Path {
+			case "/description":
+				t.Description = ""
+			case "/due_date":
+				t.DueDate = nil
+			default:
+				return t, fmt.Errorf("op %d: cannot remove %s", i, op.Path)
+			}
+		default:
+			return t, fmt.Errorf("op %d: unsupported op %q", i, op.Op)
+		}
+	}
+	return t, nil
+}
+
+func patchReplace(t *Task, op patchOp) error {
+	decode := func(dst any) error {
+		if len(op.Value) == 0 {
+			return fmt.Errorf("replace %s: value is required", op.Path)
+		}
+		if err := json.Unmarshal(op.Value, dst); err != nil {
+			return fmt.Errorf("replace %s: %w", op.Path, err)
+		}
+		return nil
+	}
+	switch op.Path {
+	case "/title":
+		if err := decode(&t.Title); err != nil {
+			return err
+		}
+		if t.Title == "" {
+			return fmt.Errorf("replace /title: title cannot be empty")
+		}
+	case "/description":
+		return decode(&t.Description)
+	case "/status":
+		if err := decode(&t.Status); err != nil {
+			return err
+		}
+		if !validStatus(t.Status) {
+			return fmt.Errorf("replace /status: invalid status %q", t.Status)
+		}
+	case "/priority":
+		return decode(&t.Priority)
+	case "/due_date":
+		return decode(&t.DueDate)
+	default:
+		return fmt.Errorf("replace %s: unknown path", op.Path)
+	}
+	return nil
+}
+
+// handlePatchTask PATCH /tasks/:id，接收 JSON Patch 操作数组。
+func (s *server) handlePatchTask(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	var ops []patchOp
+	if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json patch")
+		return
+	}
+
+	s.store.mu.Lock()
+	patched, err := applyTaskPatch(*t, ops)
+	if err != nil {
+		s.store.mu.Unlock()
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.store.snapshotTask(t)
+	t.Title = patched.Title
+	t.Description = patched.Description
+	t.Status = patched.Status
+	t.Priority = patched.Priority
+	t.DueDate = patched.DueDate
+	markCompleted(t)
+	t.UpdatedAt = time.Now()
+	s.store.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, t)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPatchReplaceFields(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "old")[0]
+
+	rec := doJSON(srv, "PATCH", fmt.Sprintf("/tasks/%d", task.ID), token, []map[string]any{
+		{"op": "replace", "path": "/title", "value": "new title"},
+		{"op": "replace", "path": "/status", "value": "done"},
+	})
+	if rec.Code != 200 {
+		t.Fatalf("patch: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	got := decodeBody[Task](t, rec)
+	if got.Title != "new title" || got.Status != "done" {
+		t.Errorf("patched task = %+v", got)
+	}
+	if got.CompletedAt == nil {
+		t.Error("CompletedAt should be set after patching to done")
+	}
+}
+
+func TestPatchRemoveNullableFields(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	due := time.Now().Add(24 * time.Hour)
+	rec := doJSON(srv, "POST", "/tasks", token, map[string]any{
+		"title": "t", "description": "desc", "due_date": due,
+	})
+	task := decodeBody[Task](t, rec)
+
+	rec = doJSON(srv, "PATCH", fmt.Sprintf("/tasks/%d", task.ID), token, []map[string]any{
+		{"op": "remove", "path": "/description"},
+		{"op": "remove", "path": "/due_date"},
+	})
+	if rec.Code != 200 {
+		t.Fatalf("patch: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	got := decodeBody[Task](t, rec)
+	if got.Description != "" || got.DueDate != nil {
+		t.Errorf("fields not cleared: %+v", got)
+	}
+}
+
+func TestPatchInvalidRejectedAtomically(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "keep me")[0]
+
+	cases := [][]map[string]any{
+		{{"op": "replace", "path": "/title", "value": "changed"},
+			{"op": "remove", "path": "/title"}}, // title 不可置空
+		{{"op": "add", "path": "/title", "value": "x"}},            // 不支持的 op
+		{{"op": "replace", "path": "/nope", "value": "x"}},         // 未知字段
+		{{"op": "replace", "path": "/status", "value": "archive"}}, // 非法状态
+	}
+	for i, ops := range cases {
+		rec := doJSON(srv, "PATCH", fmt.Sprintf("/tasks/%d", task.ID), token, ops)
+		if rec.Code != 400 {
+			t.Errorf("case %d: status = %d, want 400", i, rec.Code)
+		}
+	}
+	// 第一组里合法的 replace 也不能生效
+	got := decodeBody[Task](t,
+		doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", task.ID), token, nil))
+	if got.Title != "keep me" {
+		t.Errorf("title = %q, partial patch applied", got.Title)
+	}
+}
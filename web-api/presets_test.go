@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func presetTitles(t *testing.T, srv *server, token, preset string) []string {
+	t.Helper()
+	rec := doJSON(srv, "GET", "/tasks?preset="+preset, token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("preset %s: status = %d, body = %s", preset, rec.Code, rec.Body.String())
+	}
+	var titles []string
+	for _, item := range decodeBody[[]map[string]any](t, rec) {
+		titles = append(titles, item["title"].(string))
+	}
+	return titles
+}
+
+func TestTaskPresets(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	now := time.Now()
+	today := now.Add(time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+	nextWeek := now.AddDate(0, 0, 7)
+
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "今天到期", "due_date": today})
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "已逾期", "due_date": yesterday})
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "下周", "due_date": nextWeek})
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "紧急", "priority": 3})
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "不急", "priority": 1})
+
+	// 已完成的高优任务不出现在 high_priority 里
+	rec := doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "紧急但做完了", "priority": 3})
+	done := decodeBody[Task](t, rec)
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", done.ID), token, map[string]any{"status": "done"})
+
+	if got := presetTitles(t, srv, token, "due_today"); len(got) != 1 || got[0] != "今天到期" {
+		t.Errorf("due_today = %v", got)
+	}
+	if got := presetTitles(t, srv, token, "overdue"); len(got) != 1 || got[0] != "已逾期" {
+		t.Errorf("overdue = %v", got)
+	}
+	if got := presetTitles(t, srv, token, "high_priority"); len(got) != 1 || got[0] != "紧急" {
+		t.Errorf("high_priority = %v", got)
+	}
+}
+
+func TestTaskPresetCombinesWithFields(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "急", "priority": 3})
+
+	rec := doJSON(srv, "GET", "/tasks?preset=high_priority&fields=id,title", token, nil)
+	items := decodeBody[[]map[string]any](t, rec)
+	if len(items) != 1 {
+		t.Fatalf("items = %d, want 1", len(items))
+	}
+	if _, ok := items[0]["status"]; ok {
+		t.Error("fields filter should still apply with preset")
+	}
+}
+
+func TestTaskPresetInvalid(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "GET", "/tasks?preset=tomorrow", token, nil)
+	if rec.Code != 400 {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ActivityEntry 操作日志，批量操作记一条。
+type ActivityEntry struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Action    string    `json:"action"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// handleBatchPriority 批量调整本人任务的优先级。
+// 优先级沿用 SLA 的约定：0 无 / 1 低 / 2 中 / 3 高。
+func (s *server) handleBatchPriority(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		IDs      []int64 `json:"ids"`
+		Priority *int    `json:"priority"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "ids is required")
+		return
+	}
+	if req.Priority == nil || *req.Priority < 0 || *req.Priority > 3 {
+		writeError(w, http.StatusBadRequest, "priority must be between 0 and 3")
+		return
+	}
+
+	updated := []int64{}
+	skipped := []int64{}
+	for _, id := range req.IDs {
+		// getTask 已过滤软删除的任务
+		t := s.store.getTask(id)
+		if t == nil || t.UserID != u.ID {
+			skipped = append(skipped, id)
+			continue
+		}
+		s.store.mu.Lock()
+		t.Priority = *req.Priority
+		t.UpdatedAt = time.Now()
+		s.store.mu.Unlock()
+		updated = append(updated, id)
+	}
+
+	if len(updated) > 0 {
+		s.store.logActivity(u.ID, "batch_priority",
+			fmt.Sprintf("set priority=%d on %d task(s)", *req.Priority, len(updated)))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"updated": updated,
+		"skipped": skipped,
+	})
+}
@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestBatchPriority(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, aliceID := registerAndLogin(t, srv, "alice")
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+
+	aliceTasks := makeTasks(t, srv, aliceToken, "a", "b")
+	bobTask := makeTasks(t, srv, bobToken, "x")[0]
+
+	rec := doJSON(srv, "PATCH", "/tasks/priority", aliceToken, map[string]any{
+		"ids":      []int64{aliceTasks[0].ID, aliceTasks[1].ID, bobTask.ID},
+		"priority": 3,
+	})
+	if rec.Code != 200 {
+		t.Fatalf("batch priority: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	resp := decodeBody[struct {
+		Updated []int64 `json:"updated"`
+		Skipped []int64 `json:"skipped"`
+	}](t, rec)
+	if len(resp.Updated) != 2 {
+		t.Errorf("updated = %v, want alice's two tasks", resp.Updated)
+	}
+	// 别人的任务被跳过
+	if len(resp.Skipped) != 1 || resp.Skipped[0] != bobTask.ID {
+		t.Errorf("skipped = %v, want [%d]", resp.Skipped, bobTask.ID)
+	}
+	for _, task := range aliceTasks {
+		if got := srv.store.getTask(task.ID).Priority; got != 3 {
+			t.Errorf("task %d priority = %d, want 3", task.ID, got)
+		}
+	}
+	if srv.store.getTask(bobTask.ID).Priority == 3 {
+		t.Error("bob's task should be untouched")
+	}
+
+	// 活动日志记了一条
+	acts := srv.store.listActivitiesOf(aliceID)
+	if len(acts) != 1 || acts[0].Action != "batch_priority" {
+		t.Errorf("activities = %v", acts)
+	}
+}
+
+func TestBatchPriorityInvalidValue(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	for _, p := range []int{-1, 4} {
+		rec := doJSON(srv, "PATCH", "/tasks/priority", token,
+			map[string]any{"ids": []int64{task.ID}, "priority": p})
+		if rec.Code != 400 {
+			t.Errorf("priority %d: status = %d, want 400", p, rec.Code)
+		}
+	}
+	// 缺 priority 也拒绝
+	rec := doJSON(srv, "PATCH", "/tasks/priority", token,
+		map[string]any{"ids": []int64{task.ID}})
+	if rec.Code != 400 {
+		t.Errorf("missing priority: status = %d, want 400", rec.Code)
+	}
+}
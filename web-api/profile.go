@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// handleUpdateProfile 更新简介和公开范围设置，指针字段缺省不改。
+func (s *server) handleUpdateProfile(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		Bio       *string `json:"bio"`
+		ShowEmail *bool   `json:"show_email"`
+		ShowBio   *bool   `json:"show_bio"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	s.store.mu.Lock()
+	if req.Bio != nil {
+		u.Bio = *req.Bio
+	}
+	if req.ShowEmail != nil {
+		u.ShowEmail = *req.ShowEmail
+	}
+	if req.ShowBio != nil {
+		u.ShowBio = *req.ShowBio
+	}
+	s.store.mu.Unlock()
+	writeJSON(w, http.StatusOK, u)
+}
+
+// handlePublicProfile 别人看到的公开资料：
+// 只有用户明确设为公开的字段才出现，默认全部私密。
+func (s *server) handlePublicProfile(w http.ResponseWriter, r *http.Request, _ *User) {
+	id, err := strconv.ParseInt(param(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	target := s.store.getUser(id)
+	if target == nil {
+		writeErrCode(w, ErrUserNotFound)
+		return
+	}
+
+	profile := map[string]any{
+		"id":       target.ID,
+		"username": target.Username,
+	}
+	if target.ShowEmail {
+		profile["email"] = target.Email
+	}
+	if target.ShowBio {
+		profile["bio"] = target.Bio
+	}
+	writeJSON(w, http.StatusOK, profile)
+}
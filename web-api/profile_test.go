@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPublicProfileVisibility(t *testing.T) {
+	srv := newTestServer()
+	aliceToken, aliceID := registerAndLogin(t, srv, "alice")
+	bobToken, _ := registerAndLogin(t, srv, "bob")
+
+	// 默认全私密：bob 只能看到 id 和用户名
+	rec := doJSON(srv, "GET", fmt.Sprintf("/users/%d/public", aliceID), bobToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("public profile: status = %d", rec.Code)
+	}
+	profile := decodeBody[map[string]any](t, rec)
+	if profile["username"] != "alice" {
+		t.Errorf("username = %v", profile["username"])
+	}
+	if _, ok := profile["email"]; ok {
+		t.Error("email should be hidden by default")
+	}
+
+	// alice 公开 bio，保持邮箱私密
+	rec = doJSON(srv, "PUT", "/users/me/profile", aliceToken, map[string]any{
+		"bio": "Go 学习中", "show_bio": true,
+	})
+	if rec.Code != 200 {
+		t.Fatalf("update profile: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	rec = doJSON(srv, "GET", fmt.Sprintf("/users/%d/public", aliceID), bobToken, nil)
+	profile = decodeBody[map[string]any](t, rec)
+	if profile["bio"] != "Go 学习中" {
+		t.Errorf("bio = %v, want visible", profile["bio"])
+	}
+	if _, ok := profile["email"]; ok {
+		t.Error("email should stay hidden")
+	}
+}
+
+func TestPublicProfileNotFound(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "GET", "/users/9999/public", token, nil)
+	if rec.Code != 404 {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
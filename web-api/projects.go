@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+func (s *server) handleListProjects(w http.ResponseWriter, r *http.Request, u *User) {
+	writeJSON(w, http.StatusOK, s.store.listProjectsOf(u.ID))
+}
+
+func (s *server) handleCreateProject(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	p := s.store.createProject(req.Name, req.Description, u.ID)
+	writeJSON(w, http.StatusCreated, p)
+}
+
+// memberProject 解析 :id 并校验当前用户是项目成员。
+func (s *server) memberProject(w http.ResponseWriter, r *http.Request, u *User) (*Project, bool) {
+	id, err := strconv.ParseInt(param(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid project id")
+		return nil, false
+	}
+	p := s.store.getProject(id)
+	if p == nil {
+		writeError(w, http.StatusNotFound, "project not found")
+		return nil, false
+	}
+	if !p.isMember(u.ID) {
+		writeError(w, http.StatusForbidden, "not a project member")
+		return nil, false
+	}
+	return p, true
+}
+
+func (s *server) handleGetProject(w http.ResponseWriter, r *http.Request, u *User) {
+	p, ok := s.memberProject(w, r, u)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *server) handleUpdateProject(w http.ResponseWriter, r *http.Request, u *User) {
+	p, ok := s.memberProject(w, r, u)
+	if !ok {
+		return
+	}
+	if p.OwnerID != u.ID {
+		writeError(w, http.StatusForbidden, "only the owner can update the project")
+		return
+	}
+	var req struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	s.store.mu.Lock()
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	s.store.mu.Unlock()
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *server) handleDeleteProject(w http.ResponseWriter, r *http.Request, u *User) {
+	p, ok := s.memberProject(w, r, u)
+	if !ok {
+		return
+	}
+	if p.OwnerID != u.ID {
+		writeError(w, http.StatusForbidden, "only the owner can delete the project")
+		return
+	}
+	s.store.deleteProject(p.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) handleAddProjectMember(w http.ResponseWriter, r *http.Request, u *User) {
+	p, ok := s.memberProject(w, r, u)
+	if !ok {
+		return
+	}
+	if p.OwnerID != u.ID {
+		writeError(w, http.StatusForbidden, "only the owner can manage members")
+		return
+	}
+	uid, err := strconv.ParseInt(param(r, "userId"), 10, 64)
+	if err != nil || s.store.getUser(uid) == nil {
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	s.store.addProjectMember(p.ID, uid)
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *server) handleRemoveProjectMember(w http.ResponseWriter, r *http.Request, u *User) {
+	p, ok := s.memberProject(w, r, u)
+	if !ok {
+		return
+	}
+	if p.OwnerID != u.ID {
+		writeError(w, http.StatusForbidden, "only the owner can manage members")
+		return
+	}
+	uid, err := strconv.ParseInt(param(r, "userId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	if uid == p.OwnerID {
+		writeError(w, http.StatusBadRequest, "cannot remove the owner")
+		return
+	}
+	s.store.removeProjectMember(p.ID, uid)
+	writeJSON(w, http.StatusOK, p)
+}
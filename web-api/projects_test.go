@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestProjectCreateAndGet(t *testing.T) {
+	srv := newTestServer()
+	token, uid := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/projects", token, map[string]string{
+		"name":        "go-study",
+		"description": "learn go",
+	})
+	if rec.Code != 201 {
+		t.Fatalf("create project: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	p := decodeBody[Project](t, rec)
+	if p.OwnerID != uid || !p.isMember(uid) {
+		t.Errorf("owner should be a member: %+v", p)
+	}
+
+	rec = doJSON(srv, "GET", fmt.Sprintf("/projects/%d", p.ID), token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("get project: status = %d", rec.Code)
+	}
+}
+
+func TestProjectMemberManagement(t *testing.T) {
+	srv := newTestServer()
+	ownerToken, _ := registerAndLogin(t, srv, "owner")
+	memberToken, memberID := registerAndLogin(t, srv, "member")
+
+	rec := doJSON(srv, "POST", "/projects", ownerToken, map[string]string{"name": "p1"})
+	p := decodeBody[Project](t, rec)
+
+	// 非成员无权查看
+	rec = doJSON(srv, "GET", fmt.Sprintf("/projects/%d", p.ID), memberToken, nil)
+	if rec.Code != 403 {
+		t.Fatalf("non-member get: status = %d, want 403", rec.Code)
+	}
+
+	// 加成员后可以查看
+	rec = doJSON(srv, "POST", fmt.Sprintf("/projects/%d/members/%d", p.ID, memberID), ownerToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("add member: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	rec = doJSON(srv, "GET", fmt.Sprintf("/projects/%d", p.ID), memberToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("member get: status = %d, want 200", rec.Code)
+	}
+
+	// 普通成员不能管理成员
+	rec = doJSON(srv, "DELETE", fmt.Sprintf("/projects/%d/members/%d", p.ID, memberID), memberToken, nil)
+	if rec.Code != 403 {
+		t.Fatalf("member managing members: status = %d, want 403", rec.Code)
+	}
+
+	// 移除成员后再次无权访问
+	rec = doJSON(srv, "DELETE", fmt.Sprintf("/projects/%d/members/%d", p.ID, memberID), ownerToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("remove member: status = %d", rec.Code)
+	}
+	rec = doJSON(srv, "GET", fmt.Sprintf("/projects/%d", p.ID), memberToken, nil)
+	if rec.Code != 403 {
+		t.Fatalf("removed member get: status = %d, want 403", rec.Code)
+	}
+}
+
+func TestProjectDelete(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/projects", token, map[string]string{"name": "temp"})
+	p := decodeBody[Project](t, rec)
+
+	rec = doJSON(srv, "DELETE", fmt.Sprintf("/projects/%d", p.ID), token, nil)
+	if rec.Code != 204 {
+		t.Fatalf("delete project: status = %d", rec.Code)
+	}
+	rec = doJSON(srv, "GET", fmt.Sprintf("/projects/%d", p.ID), token, nil)
+	if rec.Code != 404 {
+		t.Fatalf("get deleted project: status = %d, want 404", rec.Code)
+	}
+}
+
+func TestProjectListOnlyMine(t *testing.T) {
+	srv := newTestServer()
+	aToken, _ := registerAndLogin(t, srv, "alice")
+	bToken, _ := registerAndLogin(t, srv, "bob")
+
+	doJSON(srv, "POST", "/projects", aToken, map[string]string{"name": "a-only"})
+	rec := doJSON(srv, "GET", "/projects", bToken, nil)
+	if got := decodeBody[[]Project](t, rec); len(got) != 0 {
+		t.Errorf("bob should see no projects, got %v", got)
+	}
+}
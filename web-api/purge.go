@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// deletedRetention 软删除任务保留多久后才物理清理。
+const deletedRetention = 30 * 24 * time.Hour
+
+// handleDeleteTask 软删除：只打标记，保留期内还能恢复/审计。
+func (s *server) handleDeleteTask(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	s.store.softDeleteTask(t.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *store) softDeleteTask(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.tasks[id]; t != nil && t.DeletedAt == nil {
+		now := time.Now()
+		t.DeletedAt = &now
+	}
+}
+
+// purgeDeletedTasks 物理清理超过保留期的软删任务。
+// 删除前先把任务序列化追加到归档文件（JSON Lines，按删除月份分文件，
+// 如 tasks-2026-08.jsonl），归档失败就不删，宁可多留不可丢数据。
+func (s *store) purgeDeletedTasks(dir string, retention time.Duration, now time.Time) (int, error) {
+	s.mu.Lock()
+	expired := []*Task{}
+	for _, t := range s.tasks {
+		if t.DeletedAt != nil && now.Sub(*t.DeletedAt) >= retention {
+			expired = append(expired, t)
+		}
+	}
+	s.mu.Unlock()
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	// 按删除月份分组写文件
+	byMonth := map[string][]*Task{}
+	for _, t := range expired {
+		byMonth[t.DeletedAt.Format("2006-01")] = append(byMonth[t.DeletedAt.Format("2006-01")], t)
+	}
+	for month, tasks := range byMonth {
+		path := filepath.Join(dir, "tasks-"+month+".jsonl")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return 0, fmt.Errorf("open archive %s: %w", path, err)
+		}
+		enc := json.NewEncoder(f)
+		for _, t := range tasks {
+			if err := enc.Encode(t); err != nil {
+				f.Close()
+				return 0, fmt.Errorf("archive task %d: %w", t.ID, err)
+			}
+		}
+		if err := f.Close(); err != nil {
+			return 0, err
+		}
+	}
+
+	s.mu.Lock()
+	for _, t := range expired {
+		delete(s.tasks, t.ID)
+	}
+	s.mu.Unlock()
+	return len(expired), nil
+}
+
+// startPurgeLoop 后台定期清理，main 里启动。
+func (s *server) startPurgeLoop(dir string, interval time.Duration) {
+	go func() {
+		for range time.Tick(interval) {
+			if _, err := s.store.purgeDeletedTasks(dir, deletedRetention, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "purge deleted tasks: %v\n", err)
+			}
+		}
+	}()
+}
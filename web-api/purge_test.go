@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPurgeArchivesBeforeDelete(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	tasks := makeTasks(t, srv, token, "old", "fresh", "alive")
+
+	rec := doJSON(srv, "DELETE", fmt.Sprintf("/tasks/%d", tasks[0].ID), token, nil)
+	if rec.Code != 204 {
+		t.Fatalf("delete: status = %d", rec.Code)
+	}
+	doJSON(srv, "DELETE", fmt.Sprintf("/tasks/%d", tasks[1].ID), token, nil)
+
+	// 软删后列表里看不到
+	rec = doJSON(srv, "GET", "/tasks", token, nil)
+	if list := decodeBody[[]Task](t, rec); len(list) != 1 || list[0].Title != "alive" {
+		t.Fatalf("list after soft delete = %v", list)
+	}
+
+	// old 超过保留期，fresh 还在保留期内
+	past := time.Now().Add(-40 * 24 * time.Hour)
+	srv.store.mu.Lock()
+	srv.store.tasks[tasks[0].ID].DeletedAt = &past
+	srv.store.mu.Unlock()
+
+	dir := t.TempDir()
+	n, err := srv.store.purgeDeletedTasks(dir, deletedRetention, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("purged %d, want 1", n)
+	}
+
+	// 物理删除已执行，fresh 保留（getTask 会过滤软删任务，这里直接看表）
+	srv.store.mu.Lock()
+	_, oldExists := srv.store.tasks[tasks[0].ID]
+	_, freshExists := srv.store.tasks[tasks[1].ID]
+	srv.store.mu.Unlock()
+	if oldExists {
+		t.Error("old task should be physically deleted")
+	}
+	if !freshExists {
+		t.Error("fresh task should survive purge")
+	}
+
+	// 归档文件按删除月份命名，内容完整
+	path := filepath.Join(dir, "tasks-"+past.Format("2006-01")+".jsonl")
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("archive file: %v", err)
+	}
+	defer f.Close()
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var archived Task
+		if err := json.Unmarshal(scanner.Bytes(), &archived); err != nil {
+			t.Fatalf("line %d: %v", lines, err)
+		}
+		if archived.ID != tasks[0].ID || archived.Title != "old" || archived.DeletedAt == nil {
+			t.Errorf("archived = %+v", archived)
+		}
+		lines++
+	}
+	if lines != 1 {
+		t.Errorf("archive lines = %d, want 1", lines)
+	}
+}
+
+func TestPurgeNothingToDo(t *testing.T) {
+	srv := newTestServer()
+	n, err := srv.store.purgeDeletedTasks(t.TempDir(), deletedRetention, time.Now())
+	if err != nil || n != 0 {
+		t.Errorf("n = %d, err = %v", n, err)
+	}
+}
+
+func TestSoftDeletedTaskNotAccessible(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	doJSON(srv, "DELETE", fmt.Sprintf("/tasks/%d", task.ID), token, nil)
+
+	// 保留期内任务对接口完全不可见：读写一律 404
+	if rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", task.ID), token, nil); rec.Code != 404 {
+		t.Errorf("get deleted: status = %d, want 404", rec.Code)
+	}
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token,
+		map[string]any{"title": "revived"})
+	if rec.Code != 404 {
+		t.Errorf("update deleted: status = %d, want 404", rec.Code)
+	}
+	rec = doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]string{"content": "hi"})
+	if rec.Code != 404 {
+		t.Errorf("comment on deleted: status = %d, want 404", rec.Code)
+	}
+}
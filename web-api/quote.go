@@ -0,0 +1,43 @@
+package main
+
+// QuotePreview 被引用评论的摘要，回复展示时用。
+type QuotePreview struct {
+	CommentID int64  `json:"comment_id"`
+	Author    string `json:"author,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	Deleted   bool   `json:"deleted,omitempty"` // 被引用的评论已不存在
+}
+
+// quoteSummaryRunes 摘要最多保留的字符数。
+const quoteSummaryRunes = 50
+
+// summarize 截取前 n 个字符做摘要，截断时补省略号；短内容原样返回。
+func summarize(content string, n int) string {
+	runes := []rune(content)
+	if len(runes) <= n {
+		return content
+	}
+	return string(runes[:n]) + "…"
+}
+
+// quotePreview 生成被引用评论的预览；评论已删除时只留占位标记。
+func (s *server) quotePreview(quoteID int64) *QuotePreview {
+	s.store.mu.Lock()
+	quoted := s.store.comments[quoteID]
+	var author string
+	if quoted != nil {
+		if u := s.store.users[quoted.UserID]; u != nil {
+			author = u.Username
+		}
+	}
+	s.store.mu.Unlock()
+
+	if quoted == nil {
+		return &QuotePreview{CommentID: quoteID, Deleted: true}
+	}
+	return &QuotePreview{
+		CommentID: quoted.ID,
+		Author:    author,
+		Summary:   summarize(quoted.Content, quoteSummaryRunes),
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	if got := summarize("短内容", 50); got != "短内容" {
+		t.Errorf("short = %q", got)
+	}
+	long := strings.Repeat("字", 60)
+	got := summarize(long, 50)
+	if got != strings.Repeat("字", 50)+"…" {
+		t.Errorf("truncated = %q", got)
+	}
+}
+
+func TestQuoteReplyPreview(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	original := makeComment(t, srv, token, task.ID, strings.Repeat("好", 60))
+
+	rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]any{"content": "同意", "quote_id": original.ID})
+	if rec.Code != 201 {
+		t.Fatalf("reply: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	reply := decodeBody[Comment](t, rec)
+	if reply.Quoted == nil {
+		t.Fatal("reply should carry quoted preview")
+	}
+	if reply.Quoted.Author != "alice" || reply.Quoted.CommentID != original.ID {
+		t.Errorf("quoted = %+v", reply.Quoted)
+	}
+	if reply.Quoted.Summary != strings.Repeat("好", 50)+"…" {
+		t.Errorf("summary = %q", reply.Quoted.Summary)
+	}
+
+	// 列表里也带预览
+	page := decodeBody[commentPage](t,
+		doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments?order=asc", task.ID), token, nil))
+	if page.Items[1].Quoted == nil || page.Items[1].Quoted.Author != "alice" {
+		t.Errorf("list quoted = %+v", page.Items[1].Quoted)
+	}
+}
+
+func TestQuoteDeletedComment(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	original := makeComment(t, srv, token, task.ID, "会消失的")
+	reply := decodeBody[Comment](t, doJSON(srv, "POST",
+		fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]any{"content": "回复", "quote_id": original.ID}))
+
+	// 被引用的评论没了：预览退化成占位标记
+	srv.store.mu.Lock()
+	delete(srv.store.comments, original.ID)
+	srv.store.mu.Unlock()
+
+	page := decodeBody[commentPage](t,
+		doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/comments", task.ID), token, nil))
+	if len(page.Items) != 1 || page.Items[0].ID != reply.ID {
+		t.Fatalf("items = %+v", page.Items)
+	}
+	q := page.Items[0].Quoted
+	if q == nil || !q.Deleted || q.Summary != "" {
+		t.Errorf("quoted after delete = %+v, want deleted placeholder", q)
+	}
+}
+
+func TestQuoteUnknownComment(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/comments", task.ID), token,
+		map[string]any{"content": "回复", "quote_id": 9999})
+	if rec.Code != 404 {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// refreshTokenTTL 刷新令牌的有效期，比访问令牌长得多。
+const refreshTokenTTL = 7 * 24 * time.Hour
+
+// RefreshToken 只存哈希，明文令牌只在签发响应里出现一次。
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	Revoked   bool
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// issueRefreshToken 生成一个不透明的刷新令牌并入库，返回明文。
+func (s *store) issueRefreshToken(userID int64, ttl time.Duration) string {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	token := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rt := &RefreshToken{
+		ID:        s.genID(),
+		UserID:    userID,
+		TokenHash: hashRefreshToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	s.refreshTokens[rt.ID] = rt
+	return token
+}
+
+// rotateRefreshToken 校验旧令牌并作废，返回其归属用户。
+// 已轮换过的令牌再次出现视为泄露，吊销该用户的全部刷新令牌。
+func (s *store) rotateRefreshToken(token string) (userID int64, ok bool) {
+	hash := hashRefreshToken(token)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rt := range s.refreshTokens {
+		if rt.TokenHash != hash {
+			continue
+		}
+		if rt.Revoked {
+			// 重用检测：整条链作废，逼持有者重新登录
+			for _, other := range s.refreshTokens {
+				if other.UserID == rt.UserID {
+					other.Revoked = true
+				}
+			}
+			return 0, false
+		}
+		if time.Now().After(rt.ExpiresAt) {
+			return 0, false
+		}
+		rt.Revoked = true
+		return rt.UserID, true
+	}
+	return 0, false
+}
+
+// handleRefresh 用刷新令牌换新的访问令牌，同时轮换刷新令牌本身。
+func (s *server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		writeError(w, http.StatusBadRequest, "refresh_token is required")
+		return
+	}
+	userID, ok := s.store.rotateRefreshToken(req.RefreshToken)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"token":         signToken(userID, s.accessTokenTTL),
+		"refresh_token": s.store.issueRefreshToken(userID, s.refreshTokenTTL),
+	})
+}
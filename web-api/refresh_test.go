@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func loginWithRefresh(t *testing.T, srv *server, username string) (token, refresh string) {
+	t.Helper()
+	doJSON(srv, "POST", "/register", "", map[string]string{
+		"username": username, "email": username + "@example.com", "password": "secret123",
+	})
+	rec := doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": username, "password": "secret123",
+	})
+	if rec.Code != 200 {
+		t.Fatalf("login: status = %d", rec.Code)
+	}
+	body := decodeBody[map[string]any](t, rec)
+	token, _ = body["token"].(string)
+	refresh, _ = body["refresh_token"].(string)
+	if token == "" || refresh == "" {
+		t.Fatalf("login body missing tokens: %v", body)
+	}
+	return token, refresh
+}
+
+func TestRefreshRotation(t *testing.T) {
+	srv := newTestServer()
+	_, refresh := loginWithRefresh(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": refresh})
+	if rec.Code != 200 {
+		t.Fatalf("refresh: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[map[string]any](t, rec)
+	newToken, _ := body["token"].(string)
+	newRefresh, _ := body["refresh_token"].(string)
+	if newToken == "" || newRefresh == "" || newRefresh == refresh {
+		t.Fatalf("rotation response = %v", body)
+	}
+
+	// 新的访问令牌可用
+	rec = doJSON(srv, "GET", "/tasks", newToken, nil)
+	if rec.Code != 200 {
+		t.Errorf("new access token: status = %d", rec.Code)
+	}
+	// 新的刷新令牌还能继续轮换
+	rec = doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": newRefresh})
+	if rec.Code != 200 {
+		t.Errorf("second rotation: status = %d", rec.Code)
+	}
+}
+
+func TestRefreshReuseRevokesChain(t *testing.T) {
+	srv := newTestServer()
+	_, refresh := loginWithRefresh(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": refresh})
+	body := decodeBody[map[string]any](t, rec)
+	newRefresh, _ := body["refresh_token"].(string)
+
+	// 旧令牌再次出现：视为泄露
+	rec = doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": refresh})
+	if rec.Code != 401 {
+		t.Fatalf("reused token: status = %d, want 401", rec.Code)
+	}
+	// 链上最新的令牌也被吊销
+	rec = doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": newRefresh})
+	if rec.Code != 401 {
+		t.Errorf("chained token after reuse: status = %d, want 401", rec.Code)
+	}
+}
+
+func TestRefreshExpired(t *testing.T) {
+	srv := newTestServer()
+	_, refresh := loginWithRefresh(t, srv, "alice")
+
+	srv.store.mu.Lock()
+	for _, rt := range srv.store.refreshTokens {
+		rt.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+	srv.store.mu.Unlock()
+
+	rec := doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": refresh})
+	if rec.Code != 401 {
+		t.Errorf("expired token: status = %d, want 401", rec.Code)
+	}
+}
+
+func TestRefreshMissingToken(t *testing.T) {
+	srv := newTestServer()
+	rec := doJSON(srv, "POST", "/auth/refresh", "", map[string]string{})
+	if rec.Code != 400 {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+	rec = doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": "bogus"})
+	if rec.Code != 401 {
+		t.Errorf("unknown token: status = %d, want 401", rec.Code)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// handleReorderTask 看板拖拽排序。
+// 客户端可以直接给 position，也可以给目标前后的任务 id，
+// 服务端用浮点中点法算出新位置，避免每次拖拽都重排整列。
+func (s *server) handleReorderTask(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	var req struct {
+		Position *float64 `json:"position"`
+		AfterID  *int64   `json:"after_id"`  // 排到这个任务之后
+		BeforeID *int64   `json:"before_id"` // 排到这个任务之前
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	var newPos float64
+	switch {
+	case req.Position != nil:
+		newPos = *req.Position
+	case req.AfterID != nil || req.BeforeID != nil:
+		var after, before *Task
+		if req.AfterID != nil {
+			after = s.store.getTask(*req.AfterID)
+			if after == nil || after.UserID != u.ID {
+				writeError(w, http.StatusBadRequest, "after_id task not found")
+				return
+			}
+		}
+		if req.BeforeID != nil {
+			before = s.store.getTask(*req.BeforeID)
+			if before == nil || before.UserID != u.ID {
+				writeError(w, http.StatusBadRequest, "before_id task not found")
+				return
+			}
+		}
+		siblings := s.store.listTasksOf(u.ID)
+		sortTasksByPosition(siblings)
+		switch {
+		case after != nil && before != nil:
+			newPos = (after.Position + before.Position) / 2
+		case after != nil:
+			// 排到 after 之后：和它的后一个任务取中点，
+			// after 已是末尾才 +1（直接 +1 会和中段任务撞位置）
+			newPos = after.Position + 1
+			if next := neighborByPosition(siblings, after.ID, t.ID, 1); next != nil {
+				newPos = (after.Position + next.Position) / 2
+			}
+		default:
+			// 排到 before 之前，对称处理
+			newPos = before.Position - 1
+			if prev := neighborByPosition(siblings, before.ID, t.ID, -1); prev != nil {
+				newPos = (prev.Position + before.Position) / 2
+			}
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "position or after_id/before_id is required")
+		return
+	}
+
+	s.store.setTaskPosition(t.ID, newPos)
+	writeJSON(w, http.StatusOK, t)
+}
+
+// neighborByPosition 在按 position 排好的任务里找 anchorID 的相邻任务，
+// dir=1 向后、dir=-1 向前，跳过正在移动的 skipID；anchor 在端点时返回 nil。
+func neighborByPosition(sorted []*Task, anchorID, skipID int64, dir int) *Task {
+	var prev *Task
+	seen := false
+	for _, t := range sorted {
+		if t.ID == anchorID {
+			if dir < 0 {
+				return prev
+			}
+			seen = true
+			continue
+		}
+		if t.ID == skipID {
+			continue
+		}
+		if seen {
+			return t
+		}
+		prev = t
+	}
+	return nil
+}
+
+// sortTasksByPosition 按手动排序位置升序。
+func sortTasksByPosition(ts []*Task) {
+	sort.SliceStable(ts, func(i, j int) bool { return ts[i].Position < ts[j].Position })
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func makeTasks(t *testing.T, srv *server, token string, titles ...string) []Task {
+	t.Helper()
+	var out []Task
+	for _, title := range titles {
+		rec := doJSON(srv, "POST", "/tasks", token, map[string]any{"title": title})
+		out = append(out, decodeBody[Task](t, rec))
+	}
+	return out
+}
+
+func listOrdered(t *testing.T, srv *server, token string) []string {
+	t.Helper()
+	rec := doJSON(srv, "GET", "/tasks?sort=position", token, nil)
+	tasks := decodeBody[[]Task](t, rec)
+	var titles []string
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	return titles
+}
+
+func TestReorderBetween(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	ts := makeTasks(t, srv, token, "a", "b", "c")
+
+	// 把 c 插到 a 和 b 之间
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/reorder", ts[2].ID), token,
+		map[string]any{"after_id": ts[0].ID, "before_id": ts[1].ID})
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	moved := decodeBody[Task](t, rec)
+	wantPos := (ts[0].Position + ts[1].Position) / 2
+	if moved.Position != wantPos {
+		t.Errorf("position = %v, want midpoint %v", moved.Position, wantPos)
+	}
+
+	got := listOrdered(t, srv, token)
+	want := []string{"a", "c", "b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReorderToFrontAndBack(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	ts := makeTasks(t, srv, token, "a", "b", "c")
+
+	// c 移到最前
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/reorder", ts[2].ID), token,
+		map[string]any{"before_id": ts[0].ID})
+	// a 移到最后
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/reorder", ts[0].ID), token,
+		map[string]any{"after_id": ts[1].ID})
+
+	got := listOrdered(t, srv, token)
+	want := []string{"c", "b", "a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReorderAfterMiddleTask(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	ts := makeTasks(t, srv, token, "a", "b", "c", "d")
+
+	// after 不是末尾任务：取 b 和 c 位置的中点，不能和 c 撞位置
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/reorder", ts[3].ID), token,
+		map[string]any{"after_id": ts[1].ID})
+	moved := decodeBody[Task](t, rec)
+	if wantPos := (ts[1].Position + ts[2].Position) / 2; moved.Position != wantPos {
+		t.Errorf("position = %v, want midpoint %v", moved.Position, wantPos)
+	}
+
+	// before 不是最前任务的对称情况：此刻 c 的前一个是刚移过来的 d
+	dPos := moved.Position
+	rec = doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/reorder", ts[1].ID), token,
+		map[string]any{"before_id": ts[2].ID})
+	moved = decodeBody[Task](t, rec)
+	if wantPos := (dPos + ts[2].Position) / 2; moved.Position != wantPos {
+		t.Errorf("position = %v, want midpoint %v", moved.Position, wantPos)
+	}
+
+	got := listOrdered(t, srv, token)
+	want := []string{"a", "d", "b", "c"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReorderExplicitPosition(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	ts := makeTasks(t, srv, token, "a")
+
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d/reorder", ts[0].ID), token,
+		map[string]any{"position": 42.5})
+	if moved := decodeBody[Task](t, rec); moved.Position != 42.5 {
+		t.Errorf("position = %v, want 42.5", moved.Position)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/csv"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TagUsage 报表里的标签使用条目。
+type TagUsage struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// reportStats 汇总全站统计，锁内一次算完保证各项一致。
+func (s *store) reportStats(now time.Time) (users int, byStatus map[string]int, topTags []TagUsage, active int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users = len(s.users)
+
+	byStatus = map[string]int{"todo": 0, "doing": 0, "done": 0}
+	for _, t := range s.tasks {
+		if t.DeletedAt != nil {
+			continue
+		}
+		byStatus[t.Status]++
+	}
+
+	usage := make(map[int64]int)
+	for _, ids := range s.taskTags {
+		for _, id := range ids {
+			usage[id]++
+		}
+	}
+	for id, n := range usage {
+		if tag := s.tags[id]; tag != nil {
+			topTags = append(topTags, TagUsage{Name: tag.Name, Count: n})
+		}
+	}
+	sort.Slice(topTags, func(i, j int) bool {
+		if topTags[i].Count != topTags[j].Count {
+			return topTags[i].Count > topTags[j].Count
+		}
+		return topTags[i].Name < topTags[j].Name
+	})
+	if len(topTags) > 10 {
+		topTags = topTags[:10]
+	}
+
+	cutoff := now.AddDate(0, 0, -30)
+	for _, u := range s.users {
+		if u.LastLoginAt != nil && u.LastLoginAt.After(cutoff) {
+			active++
+		}
+	}
+	return users, byStatus, topTags, active
+}
+
+// handleAdminReport 导出全站统计报表，format=json（默认）或 csv。
+func (s *server) handleAdminReport(w http.ResponseWriter, r *http.Request, _ *User) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	users, byStatus, topTags, active := s.store.reportStats(time.Now())
+
+	switch format {
+	case "json":
+		writeJSON(w, http.StatusOK, map[string]any{
+			"users":            users,
+			"tasks_by_status":  byStatus,
+			"top_tags":         topTags,
+			"active_users_30d": active,
+		})
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="report.csv"`)
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"section", "key", "value"})
+		_ = cw.Write([]string{"users", "total", strconv.Itoa(users)})
+		for _, st := range []string{"todo", "doing", "done"} {
+			_ = cw.Write([]string{"tasks_by_status", st, strconv.Itoa(byStatus[st])})
+		}
+		for _, tu := range topTags {
+			_ = cw.Write([]string{"top_tags", tu.Name, strconv.Itoa(tu.Count)})
+		}
+		_ = cw.Write([]string{"active_users_30d", "total", strconv.Itoa(active)})
+		cw.Flush()
+	default:
+		writeError(w, http.StatusBadRequest, "format must be json or csv")
+	}
+}
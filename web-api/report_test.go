@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAdminReportJSON(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	userToken, _ := registerAndLogin(t, srv, "alice")
+
+	tasks := makeTasks(t, srv, userToken, "a", "b", "c")
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", tasks[0].ID), userToken,
+		map[string]any{"status": "done"})
+	tag := makeTag(t, srv, userToken, "work", nil)
+	for _, task := range tasks[:2] {
+		doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/tags/%d", task.ID, tag.ID), userToken, nil)
+	}
+
+	rec := doJSON(srv, "GET", "/admin/report", adminToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("report: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	report := decodeBody[struct {
+		Users         int            `json:"users"`
+		TasksByStatus map[string]int `json:"tasks_by_status"`
+		TopTags       []TagUsage     `json:"top_tags"`
+		Active        int            `json:"active_users_30d"`
+	}](t, rec)
+
+	if report.Users != 2 {
+		t.Errorf("users = %d, want 2", report.Users)
+	}
+	if report.TasksByStatus["todo"] != 2 || report.TasksByStatus["done"] != 1 {
+		t.Errorf("tasks_by_status = %v", report.TasksByStatus)
+	}
+	if len(report.TopTags) != 1 || report.TopTags[0].Name != "work" || report.TopTags[0].Count != 2 {
+		t.Errorf("top_tags = %v", report.TopTags)
+	}
+	// 两个用户都刚登录过
+	if report.Active != 2 {
+		t.Errorf("active_users_30d = %d, want 2", report.Active)
+	}
+}
+
+func TestAdminReportCSV(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	makeTasks(t, srv, adminToken, "a")
+
+	rec := doJSON(srv, "GET", "/admin/report?format=csv", adminToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("csv report: status = %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	// 表头 + users + 三个状态 + 活跃数（无标签）
+	if len(rows) != 6 {
+		t.Fatalf("rows = %d: %v", len(rows), rows)
+	}
+	if rows[1][0] != "users" || rows[1][2] != "1" {
+		t.Errorf("users row = %v", rows[1])
+	}
+	found := false
+	for _, row := range rows {
+		if row[0] == "tasks_by_status" && row[1] == "todo" && row[2] == "1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("missing tasks_by_status todo row")
+	}
+}
+
+func TestAdminReportBadFormat(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin")
+	rec := doJSON(srv, "GET", "/admin/report?format=xml", adminToken, nil)
+	if rec.Code != 400 {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
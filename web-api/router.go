@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// router 是一个最小实现的路由器，支持 `/projects/:id` 形式的路径参数。
+// Go 1.21 的 ServeMux 还不支持方法和参数匹配，自己写一个刚好够用。
+type router struct {
+	routes []routeEntry
+}
+
+type routeEntry struct {
+	method  string
+	parts   []string
+	handler http.HandlerFunc
+}
+
+type paramsKey struct{}
+
+func newRouter() *router {
+	return &router{}
+}
+
+// handle 注册一条路由，pattern 形如 "/projects/:id/members/:userId"。
+func (rt *router) handle(method, pattern string, h http.HandlerFunc) {
+	rt.routes = append(rt.routes, routeEntry{
+		method:  method,
+		parts:   splitPath(pattern),
+		handler: h,
+	})
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := splitPath(r.URL.Path)
+	pathMatched := false
+	for _, e := range rt.routes {
+		params, ok := matchParts(e.parts, parts)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if e.method != r.Method {
+			continue
+		}
+		ctx := context.WithValue(r.Context(), paramsKey{}, params)
+		e.handler(w, r.WithContext(ctx))
+		return
+	}
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+// param 取出路径参数，未注册的名字返回空串。
+func param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params[name]
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func matchParts(pattern, parts []string) (map[string]string, bool) {
+	if len(pattern) != len(parts) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, pp := range pattern {
+		if strings.HasPrefix(pp, ":") {
+			params[pp[1:]] = parts[i]
+			continue
+		}
+		if pp != parts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
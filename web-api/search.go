@@ -0,0 +1,75 @@
+package main
+
+import (
+	"html"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// Highlight 给 text 中匹配 keyword 的部分（大小写不敏感）包上 <mark>。
+// 先转义再拼接，text 里带的 HTML 不会原样进到输出里。
+// 按 rune 逐个小写后比较：strings.ToLower 可能改变字节长度（如 "İ"），
+// 用它的字节偏移切原串会切到半个字符。
+func Highlight(text, keyword string) string {
+	if keyword == "" {
+		return html.EscapeString(text)
+	}
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	key := []rune(keyword)
+	for i, r := range key {
+		key[i] = unicode.ToLower(r)
+	}
+
+	var b strings.Builder
+	pos := 0 // 还没输出的那一段的起点
+	for i := 0; i+len(key) <= len(lower); {
+		match := true
+		for j, r := range key {
+			if lower[i+j] != r {
+				match = false
+				break
+			}
+		}
+		if !match {
+			i++
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:i])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[i : i+len(key)])))
+		b.WriteString("</mark>")
+		i += len(key)
+		pos = i
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:])))
+	return b.String()
+}
+
+// handleSearchTasks 按关键词搜索当前用户的任务，
+// 命中的任务额外返回高亮后的 title_html/description_html。
+func (s *server) handleSearchTasks(w http.ResponseWriter, r *http.Request, u *User) {
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		writeError(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	key := strings.ToLower(q)
+
+	items := []map[string]any{}
+	for _, t := range s.store.listTasksOf(u.ID) {
+		if !strings.Contains(strings.ToLower(t.Title), key) &&
+			!strings.Contains(strings.ToLower(t.Description), key) {
+			continue
+		}
+		item := taskView(t, u)
+		item["title_html"] = Highlight(t.Title, q)
+		item["description_html"] = Highlight(t.Description, q)
+		items = append(items, item)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items, "total": len(items)})
+}
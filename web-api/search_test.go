@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	cases := []struct {
+		text, keyword, want string
+	}{
+		{"学习 Go 语言", "go", "学习 <mark>Go</mark> 语言"},
+		{"go go go", "go", "<mark>go</mark> <mark>go</mark> <mark>go</mark>"},
+		{"no match here", "xyz", "no match here"},
+		{"plain", "", "plain"},
+		// 文本里的 HTML 被转义，关键词照常高亮
+		{"<b>go</b> fast", "go", "&lt;b&gt;<mark>go</mark>&lt;/b&gt; fast"},
+		{"a < b", "b", "a &lt; <mark>b</mark>"},
+		// 小写后字节数会变的字符（İ），输出必须还是完整的原字符
+		{"İİİ", "i", "<mark>İ</mark><mark>İ</mark><mark>İ</mark>"},
+		{"İstanbul", "istanbul", "<mark>İstanbul</mark>"},
+	}
+	for _, c := range cases {
+		if got := Highlight(c.text, c.keyword); got != c.want {
+			t.Errorf("Highlight(%q, %q) = %q, want %q", c.text, c.keyword, got, c.want)
+		}
+	}
+}
+
+func TestSearchTasksHighlights(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	makeTasks(t, srv, token, "学 Go 泛型", "买菜")
+	doJSON(srv, "POST", "/tasks", token, map[string]any{
+		"title": "复习", "description": "go channel 和 goroutine",
+	})
+
+	rec := doJSON(srv, "GET", "/tasks/search?q=go", token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("search: status = %d", rec.Code)
+	}
+	page := decodeBody[struct {
+		Items []map[string]any `json:"items"`
+		Total int              `json:"total"`
+	}](t, rec)
+	if page.Total != 2 {
+		t.Fatalf("total = %d, want 2 (title and description matches)", page.Total)
+	}
+	if page.Items[0]["title_html"] != "学 <mark>Go</mark> 泛型" {
+		t.Errorf("title_html = %v", page.Items[0]["title_html"])
+	}
+	if page.Items[1]["description_html"] != "<mark>go</mark> channel 和 <mark>go</mark>routine" {
+		t.Errorf("description_html = %v", page.Items[1]["description_html"])
+	}
+}
+
+func TestSearchTasksRequiresQuery(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "GET", "/tasks/search", token, nil)
+	if rec.Code != 400 {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// server 聚合了路由和存储，本身实现 http.Handler。
+type server struct {
+	router    *router
+	store     *store
+	limiter   *rateLimiter
+	sensitive *SensitiveFilter
+	captchas  *captchaStore
+	guard     *loginGuard
+	slaConfig SLAConfig
+	confirms  *confirmStore
+	nonces    *nonceStore
+	tagLimit  int // 单任务标签数上限
+
+	commentEditWindow time.Duration // 评论发出后多久内允许编辑
+	commentLocks      *lockStore
+
+	accessTokenTTL  time.Duration // 访问令牌有效期
+	refreshTokenTTL time.Duration // 刷新令牌有效期
+	revoked         *jtiBlacklist // 登出后的令牌黑名单
+}
+
+// serverOption 创建 server 时的可选配置，仿照 basics 里 DatabaseOption 的写法。
+type serverOption func(*server)
+
+// WithAccessTokenTTL 自定义访问令牌有效期。
+func WithAccessTokenTTL(ttl time.Duration) serverOption {
+	return func(s *server) { s.accessTokenTTL = ttl }
+}
+
+// WithRefreshTokenTTL 自定义刷新令牌有效期。
+func WithRefreshTokenTTL(ttl time.Duration) serverOption {
+	return func(s *server) { s.refreshTokenTTL = ttl }
+}
+
+func newServer(opts ...serverOption) *server {
+	s := &server{
+		router:    newRouter(),
+		store:     newStore(),
+		limiter:   newRateLimiter(defaultRateLimit, defaultRateWindow),
+		sensitive: NewSensitiveFilter(defaultSensitiveWords, FilterModeReplace),
+		captchas:  newCaptchaStore(5 * time.Minute),
+		guard:     newLoginGuard(),
+		slaConfig: defaultSLAConfig,
+		confirms:  newConfirmStore(2 * time.Minute),
+		nonces:    newNonceStore(nonceWindow),
+		tagLimit:  defaultTagLimit,
+
+		commentEditWindow: defaultCommentEditWindow,
+		commentLocks:      newLockStore(defaultCommentLockTTL),
+
+		accessTokenTTL:  tokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+		revoked:         newJTIBlacklist(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.routes()
+	return s
+}
+
+func (s *server) routes() {
+	s.router.handle("GET", "/ping", s.handlePing)
+
+	s.router.handle("POST", "/register", s.handleRegister)
+	s.router.handle("POST", "/login", s.handleLogin)
+	s.router.handle("GET", "/auth/captcha", s.handleCaptcha)
+	s.router.handle("POST", "/auth/refresh", s.handleRefresh)
+	s.router.handle("POST", "/auth/logout", s.requireAuth(s.handleLogout))
+
+	s.router.handle("GET", "/tasks", s.requireAuth(s.handleListTasks))
+	s.router.handle("POST", "/tasks", s.requireAuth(s.handleCreateTask))
+	s.router.handle("GET", "/tasks/calendar.ics", s.handleCalendarFeed)
+	s.router.handle("GET", "/tasks/sla-breaches", s.requireAuth(s.handleSLABreaches))
+	s.router.handle("GET", "/tasks/completion-trend", s.requireAuth(s.handleCompletionTrend))
+	s.router.handle("GET", "/tasks/search", s.requireAuth(s.handleSearchTasks))
+	s.router.handle("GET", "/leaderboard", s.requireAuth(s.handleLeaderboard))
+	s.router.handle("GET", "/tasks/:id", s.requireAuth(s.handleGetTask))
+	s.router.handle("PUT", "/tasks/:id", s.requireAuth(s.handleUpdateTask))
+	// 注意：PATCH 的静态路由 /tasks/priority 要先注册，否则会被 :id 吃掉
+	s.router.handle("PATCH", "/tasks/priority", s.requireAuth(s.handleBatchPriority))
+	s.router.handle("PATCH", "/tasks/:id", s.requireAuth(s.handlePatchTask))
+	s.router.handle("DELETE", "/tasks/:id", s.requireAuth(s.handleDeleteTask))
+	s.router.handle("POST", "/tasks/:id/watch", s.requireAuth(s.handleWatchTask))
+	s.router.handle("DELETE", "/tasks/:id/watch", s.requireAuth(s.handleUnwatchTask))
+	s.router.handle("GET", "/notifications", s.requireAuth(s.handleListNotifications))
+	s.router.handle("PUT", "/tasks/:id/reorder", s.requireAuth(s.handleReorderTask))
+	s.router.handle("GET", "/tasks/:id/history", s.requireAuth(s.handleTaskHistory))
+	s.router.handle("POST", "/tasks/:id/rollback/:snapshotId", s.requireAuth(s.handleRollbackTask))
+	s.router.handle("POST", "/tasks/:id/time", s.requireAuth(s.handleCreateTimeEntry))
+	s.router.handle("GET", "/tasks/:id/time", s.requireAuth(s.handleListTimeEntries))
+	s.router.handle("GET", "/tasks/:id/time-report", s.requireAuth(s.handleTimeReport))
+	s.router.handle("GET", "/users/me/time/today", s.requireAuth(s.handleFocusToday))
+	s.router.handle("GET", "/users/me/calendar-token", s.requireAuth(s.handleCalendarToken))
+	s.router.handle("GET", "/users/me/export", s.requireAuth(s.handleExport))
+	s.router.handle("POST", "/users/2fa/enable", s.requireAuth(s.requireNonce(s.handleEnable2FA)))
+	s.router.handle("PUT", "/users/me/profile", s.requireAuth(s.handleUpdateProfile))
+	s.router.handle("GET", "/users/:id/public", s.requireAuth(s.handlePublicProfile))
+	s.router.handle("PUT", "/users/username", s.requireAuth(s.handleChangeUsername))
+	s.router.handle("GET", "/users/me/username-history", s.requireAuth(s.handleUsernameHistory))
+
+	s.router.handle("POST", "/tasks/:id/attachments", s.requireAuth(s.handleUploadAttachment))
+	s.router.handle("GET", "/tasks/:id/attachments", s.requireAuth(s.handleListAttachments))
+
+	s.router.handle("POST", "/tasks/:id/comments", s.requireAuth(s.handleCreateComment))
+	s.router.handle("POST", "/tasks/:id/mark-read", s.requireAuth(s.handleMarkCommentsRead))
+	s.router.handle("GET", "/tasks/:id/comments", s.requireAuth(s.handleListComments))
+	s.router.handle("PUT", "/tasks/:id/comments/:commentId", s.requireAuth(s.handleEditComment))
+	s.router.handle("POST", "/tasks/:id/comments/:commentId/like", s.requireAuth(s.handleLikeComment))
+	s.router.handle("POST", "/comments/:commentId/lock", s.requireAuth(s.handleLockComment))
+	s.router.handle("DELETE", "/comments/:commentId/lock", s.requireAuth(s.handleUnlockComment))
+
+	s.router.handle("GET", "/tags", s.requireAuth(s.handleListTags))
+	s.router.handle("POST", "/tags", s.requireAuth(s.handleCreateTag))
+	s.router.handle("GET", "/tags/tree", s.requireAuth(s.handleTagTree))
+	s.router.handle("GET", "/tags/cloud", s.requireAuth(s.handleTagCloud))
+	s.router.handle("DELETE", "/tags/:tagId", s.requireAuth(s.handleDeleteTag))
+	s.router.handle("PUT", "/tags/:tagId/parent", s.requireAuth(s.handleReparentTag))
+	s.router.handle("GET", "/tags/:tagId/tasks", s.requireAuth(s.handleTagGroupTasks))
+	s.router.handle("GET", "/templates", s.requireAuth(s.handleListTemplates))
+	s.router.handle("POST", "/templates", s.requireAuth(s.handleCreateTemplate))
+	s.router.handle("POST", "/tasks/from-template/:templateId", s.requireAuth(s.handleCreateTaskFromTemplate))
+
+	s.router.handle("POST", "/tasks/batch-status", s.requireAuth(s.handleBatchStatus))
+	s.router.handle("POST", "/tasks/tags/batch", s.requireAuth(s.handleBatchTags))
+	s.router.handle("POST", "/tasks/:id/tags/:tagId", s.requireAuth(s.handleAttachTag))
+	s.router.handle("DELETE", "/tasks/:id/tags/:tagId", s.requireAuth(s.handleDetachTag))
+
+	s.router.handle("GET", "/debug/runtime", s.requireAdmin(s.handleDebugRuntime))
+	s.router.handle("GET", "/admin/users", s.requireAdmin(s.handleAdminListUsers))
+	s.router.handle("GET", "/admin/report", s.requireAdmin(s.handleAdminReport))
+	s.router.handle("GET", "/admin/users/:id/activity", s.requireAdmin(s.handleUserActivity))
+	s.router.handle("DELETE", "/admin/users/:id", s.requireAdmin(s.handleAdminDeleteUser))
+
+	s.router.handle("GET", "/projects", s.requireAuth(s.handleListProjects))
+	s.router.handle("POST", "/projects", s.requireAuth(s.handleCreateProject))
+	s.router.handle("GET", "/projects/:id", s.requireAuth(s.handleGetProject))
+	s.router.handle("PUT", "/projects/:id", s.requireAuth(s.handleUpdateProject))
+	s.router.handle("DELETE", "/projects/:id", s.requireAuth(s.handleDeleteProject))
+	s.router.handle("POST", "/projects/:id/members/:userId", s.requireAuth(s.handleAddProjectMember))
+	s.router.handle("DELETE", "/projects/:id/members/:userId", s.requireAuth(s.handleRemoveProjectMember))
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.limiter.middleware(s.router).ServeHTTP(w, r)
+}
+
+func (s *server) handlePing(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"message": "pong"})
+}
+
+// writeJSON 统一 JSON 响应出口。
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 统一错误响应格式。
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
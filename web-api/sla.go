@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+// SLAConfig 按优先级配置的处理时限：任务应在创建后多久内完成。
+type SLAConfig map[int]time.Duration
+
+// defaultSLAConfig 数字越大优先级越高、时限越紧。
+// 没配置的优先级不做 SLA 跟踪。
+var defaultSLAConfig = SLAConfig{
+	3: 4 * time.Hour,
+	2: 24 * time.Hour,
+	1: 72 * time.Hour,
+}
+
+// CheckSLA 判断任务是否违反 SLA。
+// dueIn 是距离超时还剩多久，已超时为负数；
+// 已完成或优先级未配置 SLA 的任务永远不算违反。
+func CheckSLA(t *Task, cfg SLAConfig, now time.Time) (breached bool, dueIn time.Duration) {
+	sla, ok := cfg[t.Priority]
+	if !ok || t.Status == "done" {
+		return false, 0
+	}
+	deadline := t.CreatedAt.Add(sla)
+	dueIn = deadline.Sub(now)
+	return dueIn < 0, dueIn
+}
+
+// handleSLABreaches 列出当前用户所有违反 SLA 的任务。
+func (s *server) handleSLABreaches(w http.ResponseWriter, r *http.Request, u *User) {
+	now := time.Now()
+	type breach struct {
+		Task    *Task  `json:"task"`
+		Overdue string `json:"overdue"` // 已超时多久
+	}
+	breaches := []breach{}
+	for _, t := range s.store.listTasksOf(u.ID) {
+		if breached, dueIn := CheckSLA(t, s.slaConfig, now); breached {
+			breaches = append(breaches, breach{Task: t, Overdue: (-dueIn).Truncate(time.Second).String()})
+		}
+	}
+	writeJSON(w, http.StatusOK, breaches)
+}
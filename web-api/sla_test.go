@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckSLA(t *testing.T) {
+	cfg := SLAConfig{3: time.Hour, 1: 24 * time.Hour}
+	now := time.Unix(100000, 0)
+
+	fresh := &Task{Priority: 3, Status: "todo", CreatedAt: now.Add(-30 * time.Minute)}
+	if breached, dueIn := CheckSLA(fresh, cfg, now); breached || dueIn != 30*time.Minute {
+		t.Errorf("fresh: breached=%v dueIn=%v", breached, dueIn)
+	}
+
+	overdue := &Task{Priority: 3, Status: "todo", CreatedAt: now.Add(-2 * time.Hour)}
+	if breached, dueIn := CheckSLA(overdue, cfg, now); !breached || dueIn != -time.Hour {
+		t.Errorf("overdue: breached=%v dueIn=%v", breached, dueIn)
+	}
+
+	// 低优先级阈值更宽
+	lowPrio := &Task{Priority: 1, Status: "todo", CreatedAt: now.Add(-2 * time.Hour)}
+	if breached, _ := CheckSLA(lowPrio, cfg, now); breached {
+		t.Error("low priority within 24h should not breach")
+	}
+
+	done := &Task{Priority: 3, Status: "done", CreatedAt: now.Add(-48 * time.Hour)}
+	if breached, _ := CheckSLA(done, cfg, now); breached {
+		t.Error("done task should never breach")
+	}
+
+	unconfigured := &Task{Priority: 9, Status: "todo", CreatedAt: now.Add(-999 * time.Hour)}
+	if breached, _ := CheckSLA(unconfigured, cfg, now); breached {
+		t.Error("priority without SLA should not breach")
+	}
+}
+
+func TestSLABreachesEndpoint(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "urgent", "priority": 3})
+	task := decodeBody[Task](t, rec)
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "fresh", "priority": 3})
+
+	// 把第一个任务的创建时间拨回到超时
+	srv.store.mu.Lock()
+	srv.store.tasks[task.ID].CreatedAt = time.Now().Add(-5 * time.Hour)
+	srv.store.mu.Unlock()
+
+	rec = doJSON(srv, "GET", "/tasks/sla-breaches", token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	breaches := decodeBody[[]map[string]any](t, rec)
+	if len(breaches) != 1 {
+		t.Fatalf("breaches = %v, want exactly the overdue task", breaches)
+	}
+}
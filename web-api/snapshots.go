@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TaskSnapshot 任务更新前的历史版本，只存可回滚的基本字段。
+type TaskSnapshot struct {
+	ID          int64      `json:"id"`
+	TaskID      int64      `json:"task_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    int        `json:"priority"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// snapshotTask 记录任务当前字段的快照，调用方需持有 s.mu。
+func (s *store) snapshotTask(t *Task) *TaskSnapshot {
+	snap := &TaskSnapshot{
+		ID:          s.genID(),
+		TaskID:      t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueDate:     t.DueDate,
+		CreatedAt:   time.Now(),
+	}
+	s.snapshots[snap.ID] = snap
+	return snap
+}
+
+func (s *store) listSnapshotsOfTask(taskID int64) []*TaskSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*TaskSnapshot{}
+	for _, snap := range s.snapshots {
+		if snap.TaskID == taskID {
+			out = append(out, snap)
+		}
+	}
+	// id 升序即时间顺序
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1].ID > out[j].ID; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+// handleTaskHistory 列出任务的快照历史，旧的在前。
+func (s *server) handleTaskHistory(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	snaps := s.store.listSnapshotsOfTask(t.ID)
+	writeJSON(w, http.StatusOK, map[string]any{"items": snaps, "total": len(snaps)})
+}
+
+// handleRollbackTask 把任务恢复到某个快照。
+// 回滚前会先给当前状态拍一张快照，回滚本身也能再回滚。
+func (s *server) handleRollbackTask(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	snapID, err := strconv.ParseInt(param(r, "snapshotId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid snapshot id")
+		return
+	}
+
+	s.store.mu.Lock()
+	snap := s.store.snapshots[snapID]
+	if snap == nil || snap.TaskID != t.ID {
+		s.store.mu.Unlock()
+		writeError(w, http.StatusNotFound, "snapshot not found")
+		return
+	}
+	s.store.snapshotTask(t)
+	t.Title = snap.Title
+	t.Description = snap.Description
+	t.Status = snap.Status
+	t.Priority = snap.Priority
+	t.DueDate = snap.DueDate
+	markCompleted(t)
+	t.UpdatedAt = time.Now()
+	s.store.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, t)
+}
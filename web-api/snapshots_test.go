@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+type snapshotPage struct {
+	Items []TaskSnapshot `json:"items"`
+	Total int            `json:"total"`
+}
+
+func TestSnapshotCreatedOnUpdate(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "v1")[0]
+
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token, map[string]any{"title": "v2"})
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token, map[string]any{"title": "v3"})
+
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/history", task.ID), token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("history: status = %d", rec.Code)
+	}
+	page := decodeBody[snapshotPage](t, rec)
+	if page.Total != 2 {
+		t.Fatalf("total = %d, want 2", page.Total)
+	}
+	if page.Items[0].Title != "v1" || page.Items[1].Title != "v2" {
+		t.Errorf("snapshot titles = %q, %q", page.Items[0].Title, page.Items[1].Title)
+	}
+}
+
+func TestRollbackRestoresFields(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "original")[0]
+
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token, map[string]any{
+		"title": "edited", "status": "done", "priority": 3,
+	})
+	page := decodeBody[snapshotPage](t,
+		doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/history", task.ID), token, nil))
+	if len(page.Items) != 1 {
+		t.Fatalf("snapshots = %d, want 1", len(page.Items))
+	}
+
+	rec := doJSON(srv, "POST",
+		fmt.Sprintf("/tasks/%d/rollback/%d", task.ID, page.Items[0].ID), token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("rollback: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	restored := decodeBody[Task](t, rec)
+	if restored.Title != "original" || restored.Status != "todo" || restored.Priority != task.Priority {
+		t.Errorf("restored = %+v", restored)
+	}
+
+	// 回滚前的状态也留了快照
+	page = decodeBody[snapshotPage](t,
+		doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/history", task.ID), token, nil))
+	if page.Total != 2 || page.Items[1].Title != "edited" {
+		t.Errorf("history after rollback = %+v", page)
+	}
+}
+
+func TestRollbackWrongSnapshot(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	tasks := makeTasks(t, srv, token, "a", "b")
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", tasks[0].ID), token, map[string]any{"title": "a2"})
+	page := decodeBody[snapshotPage](t,
+		doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/history", tasks[0].ID), token, nil))
+
+	// 别的任务的快照不能用来回滚
+	rec := doJSON(srv, "POST",
+		fmt.Sprintf("/tasks/%d/rollback/%d", tasks[1].ID, page.Items[0].ID), token, nil)
+	if rec.Code != 404 {
+		t.Errorf("cross-task rollback: status = %d, want 404", rec.Code)
+	}
+}
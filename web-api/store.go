@@ -0,0 +1,807 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// store 是内存版的“数据库”，所有表共用一把锁，够练习用了。
+type store struct {
+	mu     sync.Mutex
+	nextID int64
+
+	users         map[int64]*User
+	usersByName   map[string]*User
+	projects      map[int64]*Project
+	tasks         map[int64]*Task
+	comments      map[int64]*Comment
+	tags          map[int64]*Tag
+	taskTags      map[int64][]int64 // task_tags 关联表：任务 ID -> 标签 ID 列表
+	templates     map[int64]*TaskTemplate
+	watchers      map[int64][]int64      // 任务 ID -> 关注者用户 ID
+	readMarks     map[[2]int64]time.Time // CommentRead：{用户 ID, 任务 ID} -> 最后已读时间
+	notices       map[int64]*Notification
+	renames       map[int64]*UsernameChange // UsernameHistory 表
+	attachments   map[int64]*Attachment
+	timeEntries   map[int64]*TimeEntry
+	activities    map[int64]*ActivityEntry
+	likes         map[int64][]int64 // 评论 ID -> 点赞用户 ID
+	snapshots     map[int64]*TaskSnapshot
+	calTokens     map[string]int64 // 日历订阅 token -> 用户 ID
+	refreshTokens map[int64]*RefreshToken
+}
+
+func newStore() *store {
+	return &store{
+		nextID:        1,
+		users:         make(map[int64]*User),
+		usersByName:   make(map[string]*User),
+		projects:      make(map[int64]*Project),
+		tasks:         make(map[int64]*Task),
+		comments:      make(map[int64]*Comment),
+		tags:          make(map[int64]*Tag),
+		taskTags:      make(map[int64][]int64),
+		templates:     make(map[int64]*TaskTemplate),
+		watchers:      make(map[int64][]int64),
+		readMarks:     make(map[[2]int64]time.Time),
+		notices:       make(map[int64]*Notification),
+		renames:       make(map[int64]*UsernameChange),
+		attachments:   make(map[int64]*Attachment),
+		timeEntries:   make(map[int64]*TimeEntry),
+		activities:    make(map[int64]*ActivityEntry),
+		likes:         make(map[int64][]int64),
+		snapshots:     make(map[int64]*TaskSnapshot),
+		calTokens:     make(map[string]int64),
+		refreshTokens: make(map[int64]*RefreshToken),
+	}
+}
+
+// genID 生成自增主键，调用方需持有 s.mu。
+func (s *store) genID() int64 {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+func (s *store) createUser(username, email, passwordHash string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.usersByName[username]; ok {
+		return nil, errors.New("username already taken")
+	}
+	u := &User{
+		ID:           s.genID(),
+		Username:     username,
+		Email:        email,
+		PasswordHash: passwordHash,
+		IsAdmin:      len(s.users) == 0, // 第一个注册的用户当管理员，练习项目够用
+		CreatedAt:    time.Now(),
+	}
+	s.users[u.ID] = u
+	s.usersByName[u.Username] = u
+	return u, nil
+}
+
+func (s *store) getUser(id int64) *User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.users[id]
+}
+
+func (s *store) recordLogin(userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u := s.users[userID]; u != nil {
+		now := time.Now()
+		u.LastLoginAt = &now
+	}
+}
+
+func (s *store) enable2FA(userID int64, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u := s.users[userID]; u != nil {
+		u.TOTPSecret = secret
+		u.TOTPEnabled = true
+	}
+}
+
+// renameUser 改名并写入历史，重名返回错误。
+func (s *store) renameUser(userID int64, newName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, taken := s.usersByName[newName]; taken {
+		return errors.New("username already taken")
+	}
+	u := s.users[userID]
+	if u == nil {
+		return errors.New("user not found")
+	}
+	change := &UsernameChange{
+		ID:        s.genID(),
+		UserID:    userID,
+		OldName:   u.Username,
+		NewName:   newName,
+		ChangedAt: time.Now(),
+	}
+	s.renames[change.ID] = change
+	delete(s.usersByName, u.Username)
+	u.Username = newName
+	s.usersByName[newName] = u
+	return nil
+}
+
+// lastUsernameChange 返回用户最近一次改名记录。
+func (s *store) lastUsernameChange(userID int64) (*UsernameChange, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var last *UsernameChange
+	for _, c := range s.renames {
+		if c.UserID == userID && (last == nil || c.ChangedAt.After(last.ChangedAt)) {
+			last = c
+		}
+	}
+	return last, last != nil
+}
+
+func (s *store) usernameHistoryOf(userID int64) []*UsernameChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*UsernameChange{}
+	for _, c := range s.renames {
+		if c.UserID == userID {
+			out = append(out, c)
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1].ID > out[j].ID; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+func (s *store) deleteUser(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u := s.users[id]; u != nil {
+		delete(s.usersByName, u.Username)
+		delete(s.users, id)
+	}
+}
+
+func (s *store) listUsers() []*User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*User{}
+	for _, u := range s.users {
+		out = append(out, u)
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1].ID > out[j].ID; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+func (s *store) getUserByName(name string) *User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usersByName[name]
+}
+
+func (s *store) createTask(userID int64, title, description string, priority int, due *time.Time) *Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	maxPos := 0.0
+	for _, t := range s.tasks {
+		if t.UserID == userID && t.Position > maxPos {
+			maxPos = t.Position
+		}
+	}
+	t := &Task{
+		ID:          s.genID(),
+		UserID:      userID,
+		Title:       title,
+		Description: description,
+		Status:      "todo",
+		Priority:    priority,
+		Position:    maxPos + 1,
+		DueDate:     due,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.tasks[t.ID] = t
+	return t
+}
+
+func (s *store) setTaskStatus(id int64, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.tasks[id]; t != nil {
+		t.Status = status
+		t.UpdatedAt = time.Now()
+		markCompleted(t)
+	}
+}
+
+// markCompleted 首次进入 done 时记录完成时间，调用方需持有 s.mu。
+func markCompleted(t *Task) {
+	if t.Status == "done" && t.CompletedAt == nil {
+		now := time.Now()
+		t.CompletedAt = &now
+	}
+}
+
+func (s *store) setTaskPosition(id int64, pos float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.tasks[id]; t != nil {
+		t.Position = pos
+		t.UpdatedAt = time.Now()
+	}
+}
+
+// getTask 按 id 取任务，软删除的视同不存在：
+// 保留期内的任务只归 purge/归档流程管，不应再被任何接口读写。
+func (s *store) getTask(id int64) *Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.tasks[id]
+	if t == nil || t.DeletedAt != nil {
+		return nil
+	}
+	return t
+}
+
+func (s *store) listTasksOf(userID int64) []*Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*Task{}
+	for _, t := range s.tasks {
+		if t.UserID == userID && t.DeletedAt == nil {
+			out = append(out, t)
+		}
+	}
+	sortTasksByID(out)
+	return out
+}
+
+// sortTasksByID 保证列表输出稳定，map 遍历本身无序。
+func sortTasksByID(ts []*Task) {
+	for i := 1; i < len(ts); i++ {
+		for j := i; j > 0 && ts[j-1].ID > ts[j].ID; j-- {
+			ts[j-1], ts[j] = ts[j], ts[j-1]
+		}
+	}
+}
+
+func (s *store) createComment(taskID, userID int64, content string) *Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := &Comment{
+		ID:        s.genID(),
+		TaskID:    taskID,
+		UserID:    userID,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+	s.comments[c.ID] = c
+	return c
+}
+
+func (s *store) listCommentsOfTask(taskID int64) []*Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*Comment{}
+	for _, c := range s.comments {
+		if c.TaskID == taskID {
+			out = append(out, c)
+		}
+	}
+	sortCommentsByID(out)
+	return out
+}
+
+func (s *store) countCommentsOfTask(taskID int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, c := range s.comments {
+		if c.TaskID == taskID {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *store) listCommentsOf(userID int64) []*Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*Comment{}
+	for _, c := range s.comments {
+		if c.UserID == userID {
+			out = append(out, c)
+		}
+	}
+	sortCommentsByID(out)
+	return out
+}
+
+func sortCommentsByID(cs []*Comment) {
+	for i := 1; i < len(cs); i++ {
+		for j := i; j > 0 && cs[j-1].ID > cs[j].ID; j-- {
+			cs[j-1], cs[j] = cs[j], cs[j-1]
+		}
+	}
+}
+
+// likeComment 点赞，重复点赞幂等，返回当前点赞数。
+func (s *store) likeComment(commentID, userID int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, uid := range s.likes[commentID] {
+		if uid == userID {
+			return len(s.likes[commentID])
+		}
+	}
+	s.likes[commentID] = append(s.likes[commentID], userID)
+	return len(s.likes[commentID])
+}
+
+func (s *store) likeCount(commentID int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.likes[commentID])
+}
+
+func (s *store) createTag(userID int64, name string) *Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &Tag{ID: s.genID(), UserID: userID, Name: name, CreatedAt: time.Now()}
+	s.tags[t.ID] = t
+	return t
+}
+
+func (s *store) getTag(id int64) *Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tags[id]
+}
+
+func (s *store) listTagsOf(userID int64) []*Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*Tag{}
+	for _, t := range s.tags {
+		if t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (s *store) setTagParent(tagID int64, parentID *int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.tags[tagID]; t != nil {
+		t.ParentID = parentID
+	}
+}
+
+// tagWouldCycle 判断把 tagID 挂到 newParent 下是否成环：
+// 从 newParent 沿 ParentID 往上走，碰到 tagID 就是环。
+func (s *store) tagWouldCycle(tagID, newParent int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for cur := newParent; ; {
+		if cur == tagID {
+			return true
+		}
+		t := s.tags[cur]
+		if t == nil || t.ParentID == nil {
+			return false
+		}
+		cur = *t.ParentID
+	}
+}
+
+// tagWithDescendants 返回标签自身及所有后代的 ID。
+func (s *store) tagWithDescendants(tagID int64) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []int64{tagID}
+	queue := []int64{tagID}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, t := range s.tags {
+			if t.ParentID != nil && *t.ParentID == cur {
+				out = append(out, t.ID)
+				queue = append(queue, t.ID)
+			}
+		}
+	}
+	return out
+}
+
+// tagUsageCount 统计标签关联了多少个任务。
+func (s *store) tagUsageCount(tagID int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, ids := range s.taskTags {
+		for _, id := range ids {
+			if id == tagID {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// deleteTag 删除标签并清理 task_tags 里的所有关联，相当于一个事务。
+func (s *store) deleteTag(tagID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for taskID, ids := range s.taskTags {
+		kept := ids[:0]
+		for _, id := range ids {
+			if id != tagID {
+				kept = append(kept, id)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.taskTags, taskID)
+		} else {
+			s.taskTags[taskID] = kept
+		}
+	}
+	delete(s.tags, tagID)
+}
+
+func (s *store) attachTag(taskID, tagID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range s.taskTags[taskID] {
+		if id == tagID {
+			return
+		}
+	}
+	s.taskTags[taskID] = append(s.taskTags[taskID], tagID)
+}
+
+func (s *store) detachTag(taskID, tagID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := s.taskTags[taskID]
+	for i, id := range ids {
+		if id == tagID {
+			s.taskTags[taskID] = append(ids[:i], ids[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *store) tagsOfTask(taskID int64) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64{}, s.taskTags[taskID]...)
+}
+
+func (s *store) createTemplate(userID int64, name, title, description string, priority int, tagIDs []int64) *TaskTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tpl := &TaskTemplate{
+		ID:          s.genID(),
+		UserID:      userID,
+		Name:        name,
+		Title:       title,
+		Description: description,
+		Priority:    priority,
+		TagIDs:      append([]int64{}, tagIDs...),
+	}
+	s.templates[tpl.ID] = tpl
+	return tpl
+}
+
+func (s *store) getTemplate(id int64) *TaskTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.templates[id]
+}
+
+func (s *store) listTemplatesOf(userID int64) []*TaskTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*TaskTemplate{}
+	for _, tpl := range s.templates {
+		if tpl.UserID == userID {
+			out = append(out, tpl)
+		}
+	}
+	return out
+}
+
+// markCommentsRead 记录用户看到某任务评论的时间点。
+func (s *store) markCommentsRead(userID, taskID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readMarks[[2]int64{userID, taskID}] = time.Now()
+}
+
+// unreadCommentCount 统计最后已读时间之后的新评论数。
+func (s *store) unreadCommentCount(userID, taskID int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lastRead := s.readMarks[[2]int64{userID, taskID}] // 零值表示从未读过
+	n := 0
+	for _, c := range s.comments {
+		if c.TaskID == taskID && c.CreatedAt.After(lastRead) {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *store) addWatcher(taskID, userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range s.watchers[taskID] {
+		if id == userID {
+			return
+		}
+	}
+	s.watchers[taskID] = append(s.watchers[taskID], userID)
+}
+
+func (s *store) removeWatcher(taskID, userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := s.watchers[taskID]
+	for i, id := range ids {
+		if id == userID {
+			s.watchers[taskID] = append(ids[:i], ids[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *store) watchersOf(taskID int64) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64{}, s.watchers[taskID]...)
+}
+
+func (s *store) createNotification(userID, taskID int64, message string) *Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := &Notification{
+		ID:        s.genID(),
+		UserID:    userID,
+		TaskID:    taskID,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+	s.notices[n.ID] = n
+	return n
+}
+
+func (s *store) listNotificationsOf(userID int64) []*Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*Notification{}
+	for _, n := range s.notices {
+		if n.UserID == userID {
+			out = append(out, n)
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1].ID > out[j].ID; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+func (s *store) createAttachment(taskID, userID int64, filename, contentType string, data []byte) *Attachment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := &Attachment{
+		ID:          s.genID(),
+		TaskID:      taskID,
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        len(data),
+		CreatedAt:   time.Now(),
+		data:        data,
+	}
+	s.attachments[a.ID] = a
+	return a
+}
+
+func (s *store) listAttachmentsOfTask(taskID int64) []*Attachment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*Attachment{}
+	for _, a := range s.attachments {
+		if a.TaskID == taskID {
+			out = append(out, a)
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1].ID > out[j].ID; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+// logActivity 记一条操作日志。
+func (s *store) logActivity(userID int64, action, detail string) *ActivityEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := &ActivityEntry{
+		ID:        s.genID(),
+		UserID:    userID,
+		Action:    action,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	}
+	s.activities[e.ID] = e
+	return e
+}
+
+func (s *store) listActivitiesOf(userID int64) []*ActivityEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*ActivityEntry{}
+	for _, e := range s.activities {
+		if e.UserID == userID {
+			out = append(out, e)
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1].ID > out[j].ID; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+func (s *store) createTimeEntry(taskID, userID int64, started time.Time, seconds int64) *TimeEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := &TimeEntry{
+		ID:              s.genID(),
+		TaskID:          taskID,
+		UserID:          userID,
+		StartedAt:       started,
+		DurationSeconds: seconds,
+	}
+	s.timeEntries[e.ID] = e
+	return e
+}
+
+func (s *store) listTimeEntriesOfTask(taskID int64) []*TimeEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*TimeEntry{}
+	for _, e := range s.timeEntries {
+		if e.TaskID == taskID {
+			out = append(out, e)
+		}
+	}
+	sortTimeEntriesByID(out)
+	return out
+}
+
+func (s *store) listTimeEntriesOf(userID int64) []*TimeEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*TimeEntry{}
+	for _, e := range s.timeEntries {
+		if e.UserID == userID {
+			out = append(out, e)
+		}
+	}
+	sortTimeEntriesByID(out)
+	return out
+}
+
+func sortTimeEntriesByID(es []*TimeEntry) {
+	for i := 1; i < len(es); i++ {
+		for j := i; j > 0 && es[j-1].ID > es[j].ID; j-- {
+			es[j-1], es[j] = es[j], es[j-1]
+		}
+	}
+}
+
+// calendarTokenFor 返回用户的日历 token，没有则生成。
+func (s *store) calendarTokenFor(userID int64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token, uid := range s.calTokens {
+		if uid == userID {
+			return token
+		}
+	}
+	token := randomToken()
+	s.calTokens[token] = userID
+	return token
+}
+
+func (s *store) userByCalendarToken(token string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	uid, ok := s.calTokens[token]
+	return uid, ok
+}
+
+func (s *store) createProject(name, description string, ownerID int64) *Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := &Project{
+		ID:          s.genID(),
+		Name:        name,
+		Description: description,
+		OwnerID:     ownerID,
+		MemberIDs:   []int64{ownerID},
+		CreatedAt:   time.Now(),
+	}
+	s.projects[p.ID] = p
+	return p
+}
+
+func (s *store) getProject(id int64) *Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.projects[id]
+}
+
+func (s *store) listProjectsOf(userID int64) []*Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*Project{}
+	for _, p := range s.projects {
+		if p.isMember(userID) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (s *store) deleteProject(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.projects, id)
+}
+
+func (s *store) addProjectMember(projectID, userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.projects[projectID]
+	if p == nil || p.isMember(userID) {
+		return
+	}
+	p.MemberIDs = append(p.MemberIDs, userID)
+}
+
+func (s *store) removeProjectMember(projectID, userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.projects[projectID]
+	if p == nil {
+		return
+	}
+	for i, id := range p.MemberIDs {
+		if id == userID {
+			p.MemberIDs = append(p.MemberIDs[:i], p.MemberIDs[i+1:]...)
+			return
+		}
+	}
+}
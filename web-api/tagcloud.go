@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+)
+
+// TagCloudItem 标签云里的一项，Weight 已归一化到 [0, 1]。
+type TagCloudItem struct {
+	ID     int64   `json:"id"`
+	Name   string  `json:"name"`
+	Count  int     `json:"count"`
+	Weight float64 `json:"weight"`
+}
+
+// tagUsageOf 统计用户每个标签挂了多少个任务。
+func (s *store) tagUsageOf(userID int64) map[int64]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usage := make(map[int64]int)
+	for taskID, ids := range s.taskTags {
+		t := s.tasks[taskID]
+		if t == nil || t.UserID != userID || t.DeletedAt != nil {
+			continue
+		}
+		for _, id := range ids {
+			usage[id]++
+		}
+	}
+	return usage
+}
+
+// handleTagCloud 标签云数据：权重 = 使用次数 / 最高使用次数，
+// 用得最多的标签权重 1，没挂任何任务的是 0。
+func (s *server) handleTagCloud(w http.ResponseWriter, r *http.Request, u *User) {
+	usage := s.store.tagUsageOf(u.ID)
+	max := 0
+	for _, n := range usage {
+		if n > max {
+			max = n
+		}
+	}
+
+	items := []TagCloudItem{}
+	for _, tag := range s.store.listTagsOf(u.ID) {
+		item := TagCloudItem{ID: tag.ID, Name: tag.Name, Count: usage[tag.ID]}
+		if max > 0 {
+			item.Weight = float64(item.Count) / float64(max)
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].ID < items[j].ID
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
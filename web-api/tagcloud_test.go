@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTagCloudWeights(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	tasks := makeTasks(t, srv, token, "t1", "t2", "t3", "t4")
+
+	work := makeTag(t, srv, token, "work", nil)
+	life := makeTag(t, srv, token, "life", nil)
+	idle := makeTag(t, srv, token, "idle", nil)
+
+	// work 挂 4 个任务，life 挂 2 个，idle 不挂
+	for _, task := range tasks {
+		doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/tags/%d", task.ID, work.ID), token, nil)
+	}
+	for _, task := range tasks[:2] {
+		doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/tags/%d", task.ID, life.ID), token, nil)
+	}
+
+	rec := doJSON(srv, "GET", "/tags/cloud", token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("tag cloud: status = %d", rec.Code)
+	}
+	cloud := decodeBody[struct {
+		Items []TagCloudItem `json:"items"`
+	}](t, rec)
+	if len(cloud.Items) != 3 {
+		t.Fatalf("items = %d, want 3", len(cloud.Items))
+	}
+	if cloud.Items[0].Name != "work" || cloud.Items[0].Weight != 1 {
+		t.Errorf("most used = %+v, want work with weight 1", cloud.Items[0])
+	}
+	if cloud.Items[1].Name != "life" || cloud.Items[1].Weight != 0.5 {
+		t.Errorf("life = %+v, want weight 0.5", cloud.Items[1])
+	}
+	if cloud.Items[2].ID != idle.ID || cloud.Items[2].Weight != 0 {
+		t.Errorf("unused = %+v, want weight 0", cloud.Items[2])
+	}
+}
+
+func TestTagCloudNoUsage(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	makeTag(t, srv, token, "lonely", nil)
+
+	cloud := decodeBody[struct {
+		Items []TagCloudItem `json:"items"`
+	}](t, doJSON(srv, "GET", "/tags/cloud", token, nil))
+	if len(cloud.Items) != 1 || cloud.Items[0].Weight != 0 {
+		t.Errorf("items = %+v, want single tag with weight 0", cloud.Items)
+	}
+}
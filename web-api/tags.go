@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *server) handleCreateTag(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		Name     string `json:"name"`
+		ParentID *int64 `json:"parent_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if req.ParentID != nil {
+		parent := s.store.getTag(*req.ParentID)
+		if parent == nil || parent.UserID != u.ID {
+			writeErrCode(w, ErrTagNotFound)
+			return
+		}
+	}
+	tag := s.store.createTag(u.ID, req.Name)
+	if req.ParentID != nil {
+		s.store.setTagParent(tag.ID, req.ParentID)
+	}
+	writeJSON(w, http.StatusCreated, s.store.getTag(tag.ID))
+}
+
+func (s *server) handleListTags(w http.ResponseWriter, r *http.Request, u *User) {
+	writeJSON(w, http.StatusOK, s.store.listTagsOf(u.ID))
+}
+
+// ownTag 解析 :tagId 并校验标签属于当前用户。
+func (s *server) ownTag(w http.ResponseWriter, r *http.Request, u *User) (*Tag, bool) {
+	id, err := strconv.ParseInt(param(r, "tagId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid tag id")
+		return nil, false
+	}
+	tag := s.store.getTag(id)
+	if tag == nil || tag.UserID != u.ID {
+		writeError(w, http.StatusNotFound, "tag not found")
+		return nil, false
+	}
+	return tag, true
+}
+
+// defaultTagLimit 单个任务最多挂多少个标签。
+const defaultTagLimit = 10
+
+// tagRoom 计算任务还能再挂几个标签（已挂的不重复计数）。
+func (s *server) tagRoom(taskID int64, newTags []int64) int {
+	attached := map[int64]bool{}
+	for _, id := range s.store.tagsOfTask(taskID) {
+		attached[id] = true
+	}
+	added := 0
+	for _, id := range newTags {
+		if !attached[id] {
+			added++
+		}
+	}
+	return len(attached) + added - s.tagLimit
+}
+
+func (s *server) handleAttachTag(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	tag, ok := s.ownTag(w, r, u)
+	if !ok {
+		return
+	}
+	if s.tagRoom(t.ID, []int64{tag.ID}) > 0 {
+		writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("a task can have at most %d tags", s.tagLimit))
+		return
+	}
+	s.store.attachTag(t.ID, tag.ID)
+	writeJSON(w, http.StatusOK, map[string]any{"task_id": t.ID, "tag_ids": s.store.tagsOfTask(t.ID)})
+}
+
+// handleDeleteTag 删除标签。
+// 默认拒绝删除仍被任务使用的标签；带 ?force=true 时先清理
+// task_tags 里的所有关联再删，保证不留脏数据。
+func (s *server) handleDeleteTag(w http.ResponseWriter, r *http.Request, u *User) {
+	tag, ok := s.ownTag(w, r, u)
+	if !ok {
+		return
+	}
+	force := r.URL.Query().Get("force") == "true"
+	inUse := s.store.tagUsageCount(tag.ID)
+	if inUse > 0 && !force {
+		writeError(w, http.StatusConflict,
+			fmt.Sprintf("tag is used by %d task(s), delete with force=true to detach", inUse))
+		return
+	}
+	s.store.deleteTag(tag.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleBatchTags 一次对多个任务批量加/减同一批标签。
+// 不属于当前用户的任务和标签会被跳过，不会中断整批操作。
+func (s *server) handleBatchTags(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		TaskIDs []int64 `json:"task_ids"`
+		TagIDs  []int64 `json:"tag_ids"`
+		Action  string  `json:"action"` // add / remove
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Action != "add" && req.Action != "remove" {
+		writeError(w, http.StatusBadRequest, "action must be add or remove")
+		return
+	}
+
+	var ownTags []int64
+	for _, tagID := range req.TagIDs {
+		if tag := s.store.getTag(tagID); tag != nil && tag.UserID == u.ID {
+			ownTags = append(ownTags, tagID)
+		}
+	}
+
+	// add 先整体校验上限：任何一个任务会超限就整批拒绝，不做半截操作
+	if req.Action == "add" {
+		for _, taskID := range req.TaskIDs {
+			t := s.store.getTask(taskID)
+			if t == nil || t.UserID != u.ID {
+				continue
+			}
+			if s.tagRoom(taskID, ownTags) > 0 {
+				writeError(w, http.StatusBadRequest,
+					fmt.Sprintf("task %d would exceed the %d tag limit", taskID, s.tagLimit))
+				return
+			}
+		}
+	}
+
+	affected := []int64{}
+	skipped := []int64{}
+	for _, taskID := range req.TaskIDs {
+		t := s.store.getTask(taskID)
+		if t == nil || t.UserID != u.ID {
+			skipped = append(skipped, taskID)
+			continue
+		}
+		for _, tagID := range ownTags {
+			if req.Action == "add" {
+				s.store.attachTag(taskID, tagID)
+			} else {
+				s.store.detachTag(taskID, tagID)
+			}
+		}
+		affected = append(affected, taskID)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"affected_task_ids": affected,
+		"skipped_task_ids":  skipped,
+	})
+}
+
+func (s *server) handleDetachTag(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	tag, ok := s.ownTag(w, r, u)
+	if !ok {
+		return
+	}
+	s.store.detachTag(t.ID, tag.ID)
+	writeJSON(w, http.StatusOK, map[string]any{"task_id": t.ID, "tag_ids": s.store.tagsOfTask(t.ID)})
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBatchTagAddRemove(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	ts := makeTasks(t, srv, token, "a", "b")
+
+	rec := doJSON(srv, "POST", "/tags", token, map[string]string{"name": "urgent"})
+	tag := decodeBody[Tag](t, rec)
+
+	rec = doJSON(srv, "POST", "/tasks/tags/batch", token, map[string]any{
+		"task_ids": []int64{ts[0].ID, ts[1].ID},
+		"tag_ids":  []int64{tag.ID},
+		"action":   "add",
+	})
+	if rec.Code != 200 {
+		t.Fatalf("batch add: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	for _, task := range ts {
+		if ids := srv.store.tagsOfTask(task.ID); len(ids) != 1 || ids[0] != tag.ID {
+			t.Errorf("task %d tags = %v, want [%d]", task.ID, ids, tag.ID)
+		}
+	}
+
+	rec = doJSON(srv, "POST", "/tasks/tags/batch", token, map[string]any{
+		"task_ids": []int64{ts[0].ID, ts[1].ID},
+		"tag_ids":  []int64{tag.ID},
+		"action":   "remove",
+	})
+	if rec.Code != 200 {
+		t.Fatalf("batch remove: status = %d", rec.Code)
+	}
+	for _, task := range ts {
+		if ids := srv.store.tagsOfTask(task.ID); len(ids) != 0 {
+			t.Errorf("task %d tags = %v, want empty", task.ID, ids)
+		}
+	}
+}
+
+func TestDeleteTagInUseRejected(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	rec := doJSON(srv, "POST", "/tags", token, map[string]string{"name": "used"})
+	tag := decodeBody[Tag](t, rec)
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/tags/%d", task.ID, tag.ID), token, nil)
+
+	rec = doJSON(srv, "DELETE", fmt.Sprintf("/tags/%d", tag.ID), token, nil)
+	if rec.Code != 409 {
+		t.Fatalf("delete in-use tag: status = %d, want 409", rec.Code)
+	}
+
+	rec = doJSON(srv, "DELETE", fmt.Sprintf("/tags/%d?force=true", tag.ID), token, nil)
+	if rec.Code != 204 {
+		t.Fatalf("force delete: status = %d, want 204", rec.Code)
+	}
+	if ids := srv.store.tagsOfTask(task.ID); len(ids) != 0 {
+		t.Errorf("associations not cleaned: %v", ids)
+	}
+	if srv.store.getTag(tag.ID) != nil {
+		t.Error("tag still exists after force delete")
+	}
+}
+
+func TestDeleteUnusedTag(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "POST", "/tags", token, map[string]string{"name": "unused"})
+	tag := decodeBody[Tag](t, rec)
+
+	rec = doJSON(srv, "DELETE", fmt.Sprintf("/tags/%d", tag.ID), token, nil)
+	if rec.Code != 204 {
+		t.Fatalf("delete unused tag: status = %d, want 204", rec.Code)
+	}
+}
+
+func TestBatchTagSkipsForeignTasks(t *testing.T) {
+	srv := newTestServer()
+	aToken, _ := registerAndLogin(t, srv, "alice")
+	bToken, _ := registerAndLogin(t, srv, "bob")
+
+	aTask := makeTasks(t, srv, aToken, "mine")[0]
+	bTask := makeTasks(t, srv, bToken, "theirs")[0]
+	rec := doJSON(srv, "POST", "/tags", aToken, map[string]string{"name": "t"})
+	tag := decodeBody[Tag](t, rec)
+
+	rec = doJSON(srv, "POST", "/tasks/tags/batch", aToken, map[string]any{
+		"task_ids": []int64{aTask.ID, bTask.ID},
+		"tag_ids":  []int64{tag.ID},
+		"action":   "add",
+	})
+	resp := decodeBody[map[string][]int64](t, rec)
+	if len(resp["affected_task_ids"]) != 1 || resp["affected_task_ids"][0] != aTask.ID {
+		t.Errorf("affected = %v, want only own task", resp["affected_task_ids"])
+	}
+	if len(resp["skipped_task_ids"]) != 1 || resp["skipped_task_ids"][0] != bTask.ID {
+		t.Errorf("skipped = %v, want foreign task", resp["skipped_task_ids"])
+	}
+	if ids := srv.store.tagsOfTask(bTask.ID); len(ids) != 0 {
+		t.Errorf("foreign task should be untouched, tags = %v", ids)
+	}
+}
+
+func TestTagLimitPerTask(t *testing.T) {
+	srv := newTestServer()
+	srv.tagLimit = 2
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	mkTag := func(name string) Tag {
+		rec := doJSON(srv, "POST", "/tags", token, map[string]string{"name": name})
+		return decodeBody[Tag](t, rec)
+	}
+	t1, t2, t3 := mkTag("a"), mkTag("b"), mkTag("c")
+
+	attach := func(tagID int64) int {
+		rec := doJSON(srv, "POST",
+			fmt.Sprintf("/tasks/%d/tags/%d", task.ID, tagID), token, nil)
+		return rec.Code
+	}
+	if attach(t1.ID) != 200 || attach(t2.ID) != 200 {
+		t.Fatal("attaching under the limit should succeed")
+	}
+	// 达上限后拒绝
+	if code := attach(t3.ID); code != 400 {
+		t.Errorf("over limit: status = %d, want 400", code)
+	}
+	// 重复挂已有标签不算新增，不应报错
+	if code := attach(t1.ID); code != 200 {
+		t.Errorf("re-attach existing: status = %d, want 200", code)
+	}
+	// 移除一个后又有余量
+	doJSON(srv, "DELETE", fmt.Sprintf("/tasks/%d/tags/%d", task.ID, t2.ID), token, nil)
+	if code := attach(t3.ID); code != 200 {
+		t.Errorf("after detach: status = %d, want 200", code)
+	}
+}
+
+func TestBatchTagOverLimitRejectsWhole(t *testing.T) {
+	srv := newTestServer()
+	srv.tagLimit = 2
+	token, _ := registerAndLogin(t, srv, "alice")
+	tasks := makeTasks(t, srv, token, "a", "b")
+
+	tagIDs := []int64{}
+	for _, name := range []string{"x", "y", "z"} {
+		rec := doJSON(srv, "POST", "/tags", token, map[string]string{"name": name})
+		tagIDs = append(tagIDs, decodeBody[Tag](t, rec).ID)
+	}
+
+	rec := doJSON(srv, "POST", "/tasks/tags/batch", token, map[string]any{
+		"task_ids": []int64{tasks[0].ID, tasks[1].ID},
+		"tag_ids":  tagIDs,
+		"action":   "add",
+	})
+	if rec.Code != 400 {
+		t.Fatalf("batch over limit: status = %d, want 400", rec.Code)
+	}
+	// 整体拒绝：任何任务都不应挂上标签
+	for _, task := range tasks {
+		if ids := srv.store.tagsOfTask(task.ID); len(ids) != 0 {
+			t.Errorf("task %d tags = %v, want empty after rejected batch", task.ID, ids)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// TagNode 标签树节点。
+type TagNode struct {
+	*Tag
+	Children []*TagNode `json:"children"`
+}
+
+// handleTagTree 把当前用户的标签组织成树返回，根节点在顶层。
+func (s *server) handleTagTree(w http.ResponseWriter, r *http.Request, u *User) {
+	tags := s.store.listTagsOf(u.ID)
+	nodes := map[int64]*TagNode{}
+	for _, t := range tags {
+		nodes[t.ID] = &TagNode{Tag: t, Children: []*TagNode{}}
+	}
+	roots := []*TagNode{}
+	for _, n := range nodes {
+		if n.ParentID != nil {
+			if parent, ok := nodes[*n.ParentID]; ok {
+				parent.Children = append(parent.Children, n)
+				continue
+			}
+		}
+		roots = append(roots, n)
+	}
+	sortTagNodes(roots)
+	for _, n := range nodes {
+		sortTagNodes(n.Children)
+	}
+	writeJSON(w, http.StatusOK, roots)
+}
+
+func sortTagNodes(ns []*TagNode) {
+	for i := 1; i < len(ns); i++ {
+		for j := i; j > 0 && ns[j-1].ID > ns[j].ID; j-- {
+			ns[j-1], ns[j] = ns[j], ns[j-1]
+		}
+	}
+}
+
+// handleReparentTag 调整标签的父节点，nil 表示挪到顶层。
+// 不允许把标签挂到自己或自己的后代下面，否则会成环。
+func (s *server) handleReparentTag(w http.ResponseWriter, r *http.Request, u *User) {
+	tag, ok := s.ownTag(w, r, u)
+	if !ok {
+		return
+	}
+	var req struct {
+		ParentID *int64 `json:"parent_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.ParentID != nil {
+		parent := s.store.getTag(*req.ParentID)
+		if parent == nil || parent.UserID != u.ID {
+			writeErrCode(w, ErrTagNotFound)
+			return
+		}
+		if s.store.tagWouldCycle(tag.ID, *req.ParentID) {
+			writeError(w, http.StatusBadRequest, "reparenting would create a cycle")
+			return
+		}
+	}
+	s.store.setTagParent(tag.ID, req.ParentID)
+	writeJSON(w, http.StatusOK, s.store.getTag(tag.ID))
+}
+
+// handleTagGroupTasks 查询挂了某标签或其任意后代标签的任务。
+func (s *server) handleTagGroupTasks(w http.ResponseWriter, r *http.Request, u *User) {
+	tag, ok := s.ownTag(w, r, u)
+	if !ok {
+		return
+	}
+	group := s.store.tagWithDescendants(tag.ID)
+	inGroup := map[int64]bool{}
+	for _, id := range group {
+		inGroup[id] = true
+	}
+
+	out := []*Task{}
+	for _, t := range s.store.listTasksOf(u.ID) {
+		for _, tagID := range s.store.tagsOfTask(t.ID) {
+			if inGroup[tagID] {
+				out = append(out, t)
+				break
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
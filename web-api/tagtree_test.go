@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func makeTag(t *testing.T, srv *server, token, name string, parentID *int64) Tag {
+	t.Helper()
+	body := map[string]any{"name": name}
+	if parentID != nil {
+		body["parent_id"] = *parentID
+	}
+	rec := doJSON(srv, "POST", "/tags", token, body)
+	if rec.Code != 201 {
+		t.Fatalf("create tag %s: status = %d, body = %s", name, rec.Code, rec.Body.String())
+	}
+	return decodeBody[Tag](t, rec)
+}
+
+func TestTagTree(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	frontend := makeTag(t, srv, token, "前端", nil)
+	react := makeTag(t, srv, token, "React", &frontend.ID)
+	vue := makeTag(t, srv, token, "Vue", &frontend.ID)
+	makeTag(t, srv, token, "后端", nil)
+
+	rec := doJSON(srv, "GET", "/tags/tree", token, nil)
+	tree := decodeBody[[]TagNode](t, rec)
+	if len(tree) != 2 {
+		t.Fatalf("roots = %d, want 2", len(tree))
+	}
+	if tree[0].Name != "前端" || len(tree[0].Children) != 2 {
+		t.Fatalf("frontend node = %+v", tree[0])
+	}
+	if tree[0].Children[0].ID != react.ID || tree[0].Children[1].ID != vue.ID {
+		t.Errorf("children = %v", tree[0].Children)
+	}
+}
+
+func TestTagReparentCycleRejected(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	a := makeTag(t, srv, token, "a", nil)
+	b := makeTag(t, srv, token, "b", &a.ID)
+	c := makeTag(t, srv, token, "c", &b.ID)
+
+	// a 挂到自己的后代 c 下面会成环
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tags/%d/parent", a.ID), token,
+		map[string]any{"parent_id": c.ID})
+	if rec.Code != 400 {
+		t.Errorf("cycle: status = %d, want 400", rec.Code)
+	}
+	// 挂到自己下面也是环
+	rec = doJSON(srv, "PUT", fmt.Sprintf("/tags/%d/parent", a.ID), token,
+		map[string]any{"parent_id": a.ID})
+	if rec.Code != 400 {
+		t.Errorf("self parent: status = %d, want 400", rec.Code)
+	}
+	// 正常调整没问题
+	rec = doJSON(srv, "PUT", fmt.Sprintf("/tags/%d/parent", c.ID), token,
+		map[string]any{"parent_id": a.ID})
+	if rec.Code != 200 {
+		t.Errorf("valid reparent: status = %d", rec.Code)
+	}
+}
+
+func TestTagGroupTasks(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	tasks := makeTasks(t, srv, token, "react-task", "vue-task", "other")
+
+	frontend := makeTag(t, srv, token, "前端", nil)
+	react := makeTag(t, srv, token, "React", &frontend.ID)
+	vue := makeTag(t, srv, token, "Vue", &frontend.ID)
+
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/tags/%d", tasks[0].ID, react.ID), token, nil)
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/tags/%d", tasks[1].ID, vue.ID), token, nil)
+
+	// 按父标签查，命中所有后代标签的任务
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tags/%d/tasks", frontend.ID), token, nil)
+	group := decodeBody[[]Task](t, rec)
+	if len(group) != 2 {
+		t.Fatalf("group tasks = %d, want 2", len(group))
+	}
+
+	// 按叶子标签查只有自己的
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tags/%d/tasks", react.ID), token, nil)
+	if got := decodeBody[[]Task](t, rec); len(got) != 1 || got[0].Title != "react-task" {
+		t.Errorf("react tasks = %v", got)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// highPriorityFloor preset=high_priority 里算“高优先级”的最低档。
+const highPriorityFloor = 2
+
+// applyPreset 内置的快速筛选预设，preset 为空时原样返回。
+func applyPreset(tasks []*Task, preset string, now time.Time) ([]*Task, bool) {
+	if preset == "" {
+		return tasks, true
+	}
+	keep := func(t *Task) bool { return false }
+	switch preset {
+	case "due_today":
+		y, m, d := now.Date()
+		dayStart := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		keep = func(t *Task) bool {
+			return t.DueDate != nil && !t.DueDate.Before(dayStart) && t.DueDate.Before(dayEnd)
+		}
+	case "overdue":
+		keep = func(t *Task) bool {
+			return t.DueDate != nil && t.DueDate.Before(now) && t.Status != "done"
+		}
+	case "high_priority":
+		keep = func(t *Task) bool {
+			return t.Priority >= highPriorityFloor && t.Status != "done"
+		}
+	default:
+		return nil, false
+	}
+	out := []*Task{}
+	for _, t := range tasks {
+		if keep(t) {
+			out = append(out, t)
+		}
+	}
+	return out, true
+}
+
+// taskSortFields 允许排序的字段白名单，白名单外的一律 400。
+var taskSortFields = map[string]func(a, b *Task) int{
+	"created_at": func(a, b *Task) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"due_date": func(a, b *Task) int {
+		// 没有截止时间的排最后
+		switch {
+		case a.DueDate == nil && b.DueDate == nil:
+			return 0
+		case a.DueDate == nil:
+			return 1
+		case b.DueDate == nil:
+			return -1
+		}
+		return a.DueDate.Compare(*b.DueDate)
+	},
+	"priority": func(a, b *Task) int { return a.Priority - b.Priority },
+	"title":    func(a, b *Task) int { return strings.Compare(a.Title, b.Title) },
+	"status":   func(a, b *Task) int { return strings.Compare(a.Status, b.Status) },
+}
+
+// sortTasksBy 按白名单字段排序，order=desc 反转，同值按 id 保持稳定。
+func sortTasksBy(tasks []*Task, field, order string) {
+	cmp := taskSortFields[field]
+	sort.SliceStable(tasks, func(i, j int) bool {
+		c := cmp(tasks[i], tasks[j])
+		if order == "desc" {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func (s *server) handleListTasks(w http.ResponseWriter, r *http.Request, u *User) {
+	tasks := s.store.listTasksOf(u.ID)
+	tasks, ok := applyPreset(tasks, r.URL.Query().Get("preset"), time.Now())
+	if !ok {
+		writeError(w, http.StatusBadRequest, "preset must be due_today, overdue or high_priority")
+		return
+	}
+
+	sortField := r.URL.Query().Get("sort")
+	order := r.URL.Query().Get("order")
+	if order != "" && order != "asc" && order != "desc" {
+		writeError(w, http.StatusBadRequest, "order must be asc or desc")
+		return
+	}
+	switch {
+	case sortField == "" || sortField == "position":
+		// 缺省按 id（即创建顺序）；position 是看板拖拽用的老参数
+		if sortField == "position" {
+			sortTasksByPosition(tasks)
+		}
+	default:
+		if _, allowed := taskSortFields[sortField]; !allowed {
+			writeError(w, http.StatusBadRequest, "sort must be one of created_at, due_date, priority, title, status")
+			return
+		}
+		sortTasksBy(tasks, sortField, order)
+	}
+	writeJSON(w, http.StatusOK, sparseFields(taskViews(tasks, u), r.URL.Query().Get("fields")))
+}
+
+func (s *server) handleGetTask(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	// 详情页要展示“共 N 条评论”，count 跟分页无关
+	resp := taskView(t, u)
+	resp["comments_count"] = s.store.countCommentsOfTask(t.ID)
+	resp["unread_comments"] = s.store.unreadCommentCount(u.ID, t.ID)
+	writeJSON(w, http.StatusOK, sparseFields(resp, r.URL.Query().Get("fields")))
+}
+
+func (s *server) handleCreateTask(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		Title            string     `json:"title"`
+		Description      string     `json:"description"`
+		Priority         int        `json:"priority"`
+		DueDate          *time.Time `json:"due_date"`
+		EstimatedMinutes int        `json:"estimated_minutes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+	if req.EstimatedMinutes < 0 {
+		writeError(w, http.StatusBadRequest, "estimated_minutes cannot be negative")
+		return
+	}
+	task := s.store.createTask(u.ID, req.Title, req.Description, req.Priority, req.DueDate)
+	if req.EstimatedMinutes > 0 {
+		s.store.mu.Lock()
+		task.EstimatedMinutes = req.EstimatedMinutes
+		s.store.mu.Unlock()
+	}
+	writeJSON(w, http.StatusCreated, task)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func listTitles(t *testing.T, srv *server, token, query string) []string {
+	t.Helper()
+	rec := doJSON(srv, "GET", "/tasks"+query, token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("list %q: status = %d, body = %s", query, rec.Code, rec.Body.String())
+	}
+	var titles []string
+	for _, item := range decodeBody[[]map[string]any](t, rec) {
+		titles = append(titles, item["title"].(string))
+	}
+	return titles
+}
+
+func TestTaskSortByField(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(48 * time.Hour)
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "b", "priority": 1, "due_date": later})
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "c", "priority": 3, "due_date": soon})
+	doJSON(srv, "POST", "/tasks", token, map[string]any{"title": "a", "priority": 2})
+
+	got := listTitles(t, srv, token, "?sort=priority&order=desc")
+	if len(got) != 3 || got[0] != "c" || got[1] != "a" || got[2] != "b" {
+		t.Errorf("priority desc = %v", got)
+	}
+	got = listTitles(t, srv, token, "?sort=title")
+	if got[0] != "a" || got[2] != "c" {
+		t.Errorf("title asc = %v", got)
+	}
+	// 没有截止时间的排最后
+	got = listTitles(t, srv, token, "?sort=due_date&order=asc")
+	if got[0] != "c" || got[2] != "a" {
+		t.Errorf("due_date asc = %v", got)
+	}
+}
+
+func TestTaskSortDefaultUnchanged(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	makeTasks(t, srv, token, "first", "second")
+
+	got := listTitles(t, srv, token, "")
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("default order = %v", got)
+	}
+}
+
+func TestTaskSortRejectsUnknownField(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "GET", "/tasks?sort=password", token, nil)
+	if rec.Code != 400 {
+		t.Errorf("unknown sort: status = %d, want 400", rec.Code)
+	}
+	rec = doJSON(srv, "GET", "/tasks?sort=priority&order=sideways", token, nil)
+	if rec.Code != 400 {
+		t.Errorf("bad order: status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// TaskTemplate 任务模板：重复性工作一键建任务。
+type TaskTemplate struct {
+	ID          int64   `json:"id"`
+	UserID      int64   `json:"user_id"`
+	Name        string  `json:"name"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Priority    int     `json:"priority"`
+	TagIDs      []int64 `json:"tag_ids"` // 建任务时自动打上的标签
+}
+
+func (s *server) handleCreateTemplate(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		Name        string  `json:"name"`
+		Title       string  `json:"title"`
+		Description string  `json:"description"`
+		Priority    int     `json:"priority"`
+		TagIDs      []int64 `json:"tag_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Name == "" || req.Title == "" {
+		writeError(w, http.StatusBadRequest, "name and title are required")
+		return
+	}
+	for _, tagID := range req.TagIDs {
+		if tag := s.store.getTag(tagID); tag == nil || tag.UserID != u.ID {
+			writeError(w, http.StatusBadRequest, "tag not found: "+strconv.FormatInt(tagID, 10))
+			return
+		}
+	}
+	tpl := s.store.createTemplate(u.ID, req.Name, req.Title, req.Description, req.Priority, req.TagIDs)
+	writeJSON(w, http.StatusCreated, tpl)
+}
+
+func (s *server) handleListTemplates(w http.ResponseWriter, r *http.Request, u *User) {
+	writeJSON(w, http.StatusOK, s.store.listTemplatesOf(u.ID))
+}
+
+// handleCreateTaskFromTemplate 按模板快速建任务并套用默认标签。
+func (s *server) handleCreateTaskFromTemplate(w http.ResponseWriter, r *http.Request, u *User) {
+	id, err := strconv.ParseInt(param(r, "templateId"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid template id")
+		return
+	}
+	tpl := s.store.getTemplate(id)
+	if tpl == nil || tpl.UserID != u.ID {
+		writeError(w, http.StatusNotFound, "template not found")
+		return
+	}
+	task := s.store.createTask(u.ID, tpl.Title, tpl.Description, tpl.Priority, nil)
+	for _, tagID := range tpl.TagIDs {
+		s.store.attachTag(task.ID, tagID)
+	}
+	writeJSON(w, http.StatusCreated, task)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCreateTaskFromTemplate(t *testing.T) {
+	srv := newTestServer()
+	token, uid := registerAndLogin(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/tags", token, map[string]string{"name": "weekly"})
+	tag := decodeBody[Tag](t, rec)
+
+	rec = doJSON(srv, "POST", "/templates", token, map[string]any{
+		"name":        "周报",
+		"title":       "写周报",
+		"description": "总结本周工作",
+		"priority":    2,
+		"tag_ids":     []int64{tag.ID},
+	})
+	if rec.Code != 201 {
+		t.Fatalf("create template: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	tpl := decodeBody[TaskTemplate](t, rec)
+
+	rec = doJSON(srv, "POST", fmt.Sprintf("/tasks/from-template/%d", tpl.ID), token, nil)
+	if rec.Code != 201 {
+		t.Fatalf("from template: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	task := decodeBody[Task](t, rec)
+	if task.Title != "写周报" || task.Description != "总结本周工作" || task.Priority != 2 {
+		t.Errorf("task fields = %+v", task)
+	}
+	if task.UserID != uid {
+		t.Errorf("task user = %d, want %d", task.UserID, uid)
+	}
+	if ids := srv.store.tagsOfTask(task.ID); len(ids) != 1 || ids[0] != tag.ID {
+		t.Errorf("task tags = %v, want template tag applied", ids)
+	}
+}
+
+func TestTemplateNotMine(t *testing.T) {
+	srv := newTestServer()
+	aToken, _ := registerAndLogin(t, srv, "alice")
+	bToken, _ := registerAndLogin(t, srv, "bob")
+
+	rec := doJSON(srv, "POST", "/templates", aToken, map[string]any{"name": "n", "title": "t"})
+	tpl := decodeBody[TaskTemplate](t, rec)
+
+	rec = doJSON(srv, "POST", fmt.Sprintf("/tasks/from-template/%d", tpl.ID), bToken, nil)
+	if rec.Code != 404 {
+		t.Fatalf("foreign template: status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestServer 构造测试用 server，限流放宽避免干扰用例。
+func newTestServer() *server {
+	srv := newServer()
+	srv.limiter = newRateLimiter(100000, time.Minute)
+	return srv
+}
+
+// doJSON 发起一个请求，body 为 nil 时不带请求体，token 非空时带认证头。
+func doJSON(srv *server, method, path, token string, body any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	return rec
+}
+
+// registerAndLogin 注册并登录一个用户，返回访问令牌和用户 ID。
+func registerAndLogin(t *testing.T, srv *server, username string) (token string, userID int64) {
+	t.Helper()
+	rec := doJSON(srv, "POST", "/register", "", map[string]string{
+		"username": username,
+		"email":    username + "@example.com",
+		"password": "secret123",
+	})
+	if rec.Code != 201 {
+		t.Fatalf("register %s: status = %d, body = %s", username, rec.Code, rec.Body.String())
+	}
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": username,
+		"password": "secret123",
+	})
+	if rec.Code != 200 {
+		t.Fatalf("login %s: status = %d, body = %s", username, rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Token string `json:"token"`
+		User  User   `json:"user"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Token, resp.User.ID
+}
+
+func decodeBody[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode body %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
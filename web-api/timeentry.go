@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// TimeEntry 一段番茄钟专注记录，时长用秒存，JSON 里好算。
+type TimeEntry struct {
+	ID              int64     `json:"id"`
+	TaskID          int64     `json:"task_id"`
+	UserID          int64     `json:"user_id"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationSeconds int64     `json:"duration_seconds"`
+}
+
+// handleCreateTimeEntry 记录一段专注时间，started_at 缺省为当前时间。
+func (s *server) handleCreateTimeEntry(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	var req struct {
+		StartedAt       *time.Time `json:"started_at"`
+		DurationSeconds int64      `json:"duration_seconds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.DurationSeconds <= 0 {
+		writeError(w, http.StatusBadRequest, "duration_seconds must be positive")
+		return
+	}
+	started := time.Now()
+	if req.StartedAt != nil {
+		started = *req.StartedAt
+	}
+	entry := s.store.createTimeEntry(t.ID, u.ID, started, req.DurationSeconds)
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// handleListTimeEntries 某任务的专注记录及总时长。
+func (s *server) handleListTimeEntries(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	entries := s.store.listTimeEntriesOfTask(t.ID)
+	var total int64
+	for _, e := range entries {
+		total += e.DurationSeconds
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items":         entries,
+		"total_seconds": total,
+	})
+}
+
+// handleFocusToday 当前用户今天跨任务的总专注时长。
+func (s *server) handleFocusToday(w http.ResponseWriter, r *http.Request, u *User) {
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var total int64
+	count := 0
+	for _, e := range s.store.listTimeEntriesOf(u.ID) {
+		if e.StartedAt.Before(dayStart) {
+			continue
+		}
+		total += e.DurationSeconds
+		count++
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"date":          dayStart.Format("2006-01-02"),
+		"entries":       count,
+		"total_seconds": total,
+	})
+}
+
+// handleTimeReport 估时 vs 实际耗时对比。
+// deviation_percent = (实际 - 估时) / 估时 * 100，正数代表超支；
+// 没填估时就没有偏差可言，返回 null。
+func (s *server) handleTimeReport(w http.ResponseWriter, r *http.Request, u *User) {
+	t, ok := s.ownTask(w, r, u)
+	if !ok {
+		return
+	}
+	var totalSeconds int64
+	for _, e := range s.store.listTimeEntriesOfTask(t.ID) {
+		totalSeconds += e.DurationSeconds
+	}
+	actualMinutes := float64(totalSeconds) / 60
+
+	var deviation *float64
+	if t.EstimatedMinutes > 0 {
+		d := (actualMinutes - float64(t.EstimatedMinutes)) / float64(t.EstimatedMinutes) * 100
+		deviation = &d
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task_id":           t.ID,
+		"estimated_minutes": t.EstimatedMinutes,
+		"actual_minutes":    actualMinutes,
+		"deviation_percent": deviation,
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTimeEntriesPerTask(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	for _, secs := range []int64{1500, 1500, 600} { // 两个整番茄 + 一小段
+		rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/time", task.ID), token,
+			map[string]any{"duration_seconds": secs})
+		if rec.Code != 201 {
+			t.Fatalf("record: status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/time", task.ID), token, nil)
+	resp := decodeBody[struct {
+		Items        []TimeEntry `json:"items"`
+		TotalSeconds int64       `json:"total_seconds"`
+	}](t, rec)
+	if len(resp.Items) != 3 {
+		t.Errorf("entries = %d, want 3", len(resp.Items))
+	}
+	if resp.TotalSeconds != 3600 {
+		t.Errorf("total = %d, want 3600", resp.TotalSeconds)
+	}
+}
+
+func TestTimeEntryRejectsBadDuration(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/time", task.ID), token,
+		map[string]any{"duration_seconds": 0})
+	if rec.Code != 400 {
+		t.Errorf("zero duration: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestFocusTodayAcrossTasks(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	tasks := makeTasks(t, srv, token, "a", "b")
+
+	// 今天在两个任务上各一段，外加一段昨天的（不计入）
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/time", tasks[0].ID), token,
+		map[string]any{"duration_seconds": 1500})
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/time", tasks[1].ID), token,
+		map[string]any{"duration_seconds": 900})
+	yesterday := time.Now().Add(-24 * time.Hour)
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/time", tasks[0].ID), token,
+		map[string]any{"duration_seconds": 1500, "started_at": yesterday})
+
+	rec := doJSON(srv, "GET", "/users/me/time/today", token, nil)
+	resp := decodeBody[map[string]any](t, rec)
+	if resp["total_seconds"] != float64(2400) {
+		t.Errorf("total_seconds = %v, want 2400", resp["total_seconds"])
+	}
+	if resp["entries"] != float64(2) {
+		t.Errorf("entries = %v, want 2", resp["entries"])
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+type timeReport struct {
+	TaskID           int64    `json:"task_id"`
+	EstimatedMinutes int      `json:"estimated_minutes"`
+	ActualMinutes    float64  `json:"actual_minutes"`
+	DeviationPercent *float64 `json:"deviation_percent"`
+}
+
+func getTimeReport(t *testing.T, srv *server, token string, taskID int64) timeReport {
+	t.Helper()
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d/time-report", taskID), token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("time report: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	return decodeBody[timeReport](t, rec)
+}
+
+func TestTimeReportDeviation(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "POST", "/tasks", token,
+		map[string]any{"title": "t", "estimated_minutes": 60})
+	task := decodeBody[Task](t, rec)
+
+	// 实际 90 分钟：超支 50%
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/time", task.ID), token,
+		map[string]any{"duration_seconds": 90 * 60})
+
+	report := getTimeReport(t, srv, token, task.ID)
+	if report.EstimatedMinutes != 60 || report.ActualMinutes != 90 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.DeviationPercent == nil || *report.DeviationPercent != 50 {
+		t.Errorf("deviation = %v, want 50", report.DeviationPercent)
+	}
+}
+
+func TestTimeReportNoEstimate(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/time", task.ID), token,
+		map[string]any{"duration_seconds": 600})
+
+	report := getTimeReport(t, srv, token, task.ID)
+	if report.DeviationPercent != nil {
+		t.Errorf("deviation = %v, want null without estimate", *report.DeviationPercent)
+	}
+	if report.ActualMinutes != 10 {
+		t.Errorf("actual = %v, want 10", report.ActualMinutes)
+	}
+}
+
+func TestTimeReportNoEntries(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	rec := doJSON(srv, "POST", "/tasks", token,
+		map[string]any{"title": "t", "estimated_minutes": 30})
+	task := decodeBody[Task](t, rec)
+
+	report := getTimeReport(t, srv, token, task.ID)
+	if report.ActualMinutes != 0 {
+		t.Errorf("actual = %v, want 0", report.ActualMinutes)
+	}
+	if report.DeviationPercent == nil || *report.DeviationPercent != -100 {
+		t.Errorf("deviation = %v, want -100 (nothing spent yet)", report.DeviationPercent)
+	}
+}
+
+func TestTaskEstimateUpdatable(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token,
+		map[string]any{"estimated_minutes": 45})
+	if got := decodeBody[Task](t, rec); got.EstimatedMinutes != 45 {
+		t.Errorf("estimated = %d, want 45", got.EstimatedMinutes)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiredTokenReturnsCode(t *testing.T) {
+	srv := newServer(WithAccessTokenTTL(-time.Second))
+	token, _ := loginWithRefresh(t, srv, "alice")
+
+	rec := doJSON(srv, "GET", "/tasks", token, nil)
+	if rec.Code != 401 {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+	body := decodeBody[map[string]any](t, rec)
+	if code, _ := body["code"].(float64); errCode(code) != ErrTokenExpired {
+		t.Errorf("code = %v, want %d", body["code"], ErrTokenExpired)
+	}
+}
+
+func TestCustomRefreshTTL(t *testing.T) {
+	srv := newServer(WithRefreshTokenTTL(-time.Second))
+	_, refresh := loginWithRefresh(t, srv, "alice")
+
+	rec := doJSON(srv, "POST", "/auth/refresh", "", map[string]string{"refresh_token": refresh})
+	if rec.Code != 401 {
+		t.Errorf("expired refresh: status = %d, want 401", rec.Code)
+	}
+}
+
+func TestDefaultTTLsStillWork(t *testing.T) {
+	srv := newTestServer()
+	token, refresh := loginWithRefresh(t, srv, "alice")
+
+	if rec := doJSON(srv, "GET", "/tasks", token, nil); rec.Code != 200 {
+		t.Errorf("default access token: status = %d", rec.Code)
+	}
+	if rec := doJSON(srv, "POST", "/auth/refresh", "",
+		map[string]string{"refresh_token": refresh}); rec.Code != 200 {
+		t.Errorf("default refresh token: status = %d", rec.Code)
+	}
+}
+
+func TestMalformedTokenStillGenericError(t *testing.T) {
+	srv := newTestServer()
+	rec := doJSON(srv, "GET", "/tasks", "not-a-token", nil)
+	if rec.Code != 401 {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+	body := decodeBody[map[string]any](t, rec)
+	if _, hasCode := body["code"]; hasCode {
+		t.Errorf("malformed token should not carry the expired code: %v", body)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录 B 的 SHA1 测试向量（取 8 位结果的后 6 位）。
+func TestTOTPReferenceVector(t *testing.T) {
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // "12345678901234567890"
+	if got := totpCode(secret, time.Unix(59, 0)); got != "287082" {
+		t.Errorf("totpCode(59s) = %q, want 287082", got)
+	}
+	if got := totpCode(secret, time.Unix(1111111109, 0)); got != "081804" {
+		t.Errorf("totpCode(1111111109s) = %q, want 081804", got)
+	}
+}
+
+func TestVerifyTOTPWindow(t *testing.T) {
+	secret := generateTOTPSecret()
+	now := time.Unix(1700000000, 0)
+
+	if !verifyTOTP(secret, totpCode(secret, now), now) {
+		t.Error("current code should verify")
+	}
+	if !verifyTOTP(secret, totpCode(secret, now.Add(-30*time.Second)), now) {
+		t.Error("previous window code should verify (clock skew)")
+	}
+	if verifyTOTP(secret, totpCode(secret, now.Add(-90*time.Second)), now) {
+		t.Error("expired code should be rejected")
+	}
+	if verifyTOTP(secret, "000000", now) && totpCode(secret, now) != "000000" {
+		t.Error("wrong code should be rejected")
+	}
+}
+
+func TestLoginWith2FA(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+
+	rec := doJSONNonce(srv, "POST", "/users/2fa/enable", token, "totp-test", time.Now())
+	if rec.Code != 200 {
+		t.Fatalf("enable 2fa: status = %d", rec.Code)
+	}
+	secret := decodeBody[map[string]string](t, rec)["secret"]
+
+	// 没带验证码
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123",
+	})
+	if rec.Code != 401 {
+		t.Fatalf("login without totp: status = %d, want 401", rec.Code)
+	}
+
+	// 错误验证码
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123", "totp_code": "123456",
+	})
+	if rec.Code == 200 && totpCode(secret, time.Now()) != "123456" {
+		t.Fatal("login with wrong totp should fail")
+	}
+
+	// 正确验证码
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{
+		"username": "alice", "password": "secret123", "totp_code": totpCode(secret, time.Now()),
+	})
+	if rec.Code != 200 {
+		t.Fatalf("login with valid totp: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLoginWithout2FANotRequired(t *testing.T) {
+	srv := newTestServer()
+	registerAndLogin(t, srv, "bob") // registerAndLogin 内部已验证无 2FA 时登录成功
+}
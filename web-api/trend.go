@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultTrendWeeks = 8
+	maxTrendWeeks     = 52
+)
+
+// WeekStat 一周的任务创建/完成统计。
+type WeekStat struct {
+	WeekStart      string  `json:"week_start"` // 周一，YYYY-MM-DD
+	Created        int     `json:"created"`
+	Completed      int     `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"` // 完成数 / 创建数，无创建为 0
+}
+
+// weekStartOf 求 t 所在周的周一零点。
+func weekStartOf(t time.Time) time.Time {
+	t = t.Truncate(24 * time.Hour)
+	// Go 的 Weekday 周日是 0，换算成周一为起点
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+// handleCompletionTrend 最近 N 周的完成率趋势，没有数据的周补 0。
+func (s *server) handleCompletionTrend(w http.ResponseWriter, r *http.Request, u *User) {
+	weeks, _ := strconv.Atoi(r.URL.Query().Get("weeks"))
+	if weeks <= 0 {
+		weeks = defaultTrendWeeks
+	}
+	if weeks > maxTrendWeeks {
+		weeks = maxTrendWeeks
+	}
+
+	thisWeek := weekStartOf(time.Now())
+	// 按周起始日期聚合
+	created := map[string]int{}
+	completed := map[string]int{}
+	for _, t := range s.store.listTasksOf(u.ID) {
+		created[weekStartOf(t.CreatedAt).Format("2006-01-02")]++
+		if t.CompletedAt != nil {
+			completed[weekStartOf(*t.CompletedAt).Format("2006-01-02")]++
+		}
+	}
+
+	out := []WeekStat{}
+	for i := weeks - 1; i >= 0; i-- {
+		key := thisWeek.AddDate(0, 0, -7*i).Format("2006-01-02")
+		stat := WeekStat{
+			WeekStart: key,
+			Created:   created[key],
+			Completed: completed[key],
+		}
+		if stat.Created > 0 {
+			stat.CompletionRate = float64(stat.Completed) / float64(stat.Created)
+		}
+		out = append(out, stat)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
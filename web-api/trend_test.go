@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWeekStartOf(t *testing.T) {
+	// 2026-08-31 是周一
+	monday := time.Date(2026, 8, 31, 15, 30, 0, 0, time.UTC)
+	if got := weekStartOf(monday).Format("2006-01-02"); got != "2026-08-31" {
+		t.Errorf("monday -> %s", got)
+	}
+	sunday := time.Date(2026, 9, 6, 1, 0, 0, 0, time.UTC)
+	if got := weekStartOf(sunday).Format("2006-01-02"); got != "2026-08-31" {
+		t.Errorf("sunday -> %s", got)
+	}
+}
+
+func TestCompletionTrend(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	tasks := makeTasks(t, srv, token, "a", "b", "c")
+
+	// a、b 本周创建并完成 a；c 挪到上周创建且上周完成
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", tasks[0].ID), token,
+		map[string]string{"status": "done"})
+	if rec.Code != 200 {
+		t.Fatalf("complete a: %d", rec.Code)
+	}
+	lastWeek := time.Now().AddDate(0, 0, -7)
+	srv.store.mu.Lock()
+	c := srv.store.tasks[tasks[2].ID]
+	c.CreatedAt = lastWeek
+	c.Status = "done"
+	c.CompletedAt = &lastWeek
+	srv.store.mu.Unlock()
+
+	rec = doJSON(srv, "GET", "/tasks/completion-trend?weeks=3", token, nil)
+	if rec.Code != 200 {
+		t.Fatalf("trend: %d", rec.Code)
+	}
+	stats := decodeBody[[]WeekStat](t, rec)
+	if len(stats) != 3 {
+		t.Fatalf("weeks = %d, want 3", len(stats))
+	}
+
+	// 最早一周无数据补 0
+	if stats[0].Created != 0 || stats[0].Completed != 0 || stats[0].CompletionRate != 0 {
+		t.Errorf("empty week = %+v", stats[0])
+	}
+	// 上周：1 创建 1 完成
+	if stats[1].Created != 1 || stats[1].Completed != 1 || stats[1].CompletionRate != 1 {
+		t.Errorf("last week = %+v", stats[1])
+	}
+	// 本周：2 创建 1 完成，完成率 0.5
+	if stats[2].Created != 2 || stats[2].Completed != 1 || stats[2].CompletionRate != 0.5 {
+		t.Errorf("this week = %+v", stats[2])
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// usernameCooldown 两次改名之间的最短间隔，防滥用。
+const usernameCooldown = 30 * 24 * time.Hour
+
+// UsernameChange 改名历史记录。
+type UsernameChange struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	OldName   string    `json:"old_name"`
+	NewName   string    `json:"new_name"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// handleChangeUsername 修改用户名：唯一性校验 + 30 天冷却期。
+func (s *server) handleChangeUsername(w http.ResponseWriter, r *http.Request, u *User) {
+	var req struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if req.Username == "" {
+		writeError(w, http.StatusBadRequest, "username is required")
+		return
+	}
+	if req.Username == u.Username {
+		writeError(w, http.StatusBadRequest, "same as current username")
+		return
+	}
+	if last, ok := s.store.lastUsernameChange(u.ID); ok {
+		if wait := usernameCooldown - time.Since(last.ChangedAt); wait > 0 {
+			writeError(w, http.StatusTooManyRequests,
+				"username can only be changed once every 30 days")
+			return
+		}
+	}
+	if err := s.store.renameUser(u.ID, req.Username); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (s *server) handleUsernameHistory(w http.ResponseWriter, r *http.Request, u *User) {
+	writeJSON(w, http.StatusOK, s.store.usernameHistoryOf(u.ID))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChangeUsername(t *testing.T) {
+	srv := newTestServer()
+	token, uid := registerAndLogin(t, srv, "alice")
+	registerAndLogin(t, srv, "bob")
+
+	// 重名拒绝
+	rec := doJSON(srv, "PUT", "/users/username", token, map[string]string{"username": "bob"})
+	if rec.Code != 409 {
+		t.Fatalf("duplicate name: status = %d, want 409", rec.Code)
+	}
+
+	// 改名成功并写入历史
+	rec = doJSON(srv, "PUT", "/users/username", token, map[string]string{"username": "alice2"})
+	if rec.Code != 200 {
+		t.Fatalf("rename: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if u := srv.store.getUser(uid); u.Username != "alice2" {
+		t.Errorf("username = %q, want alice2", u.Username)
+	}
+	rec = doJSON(srv, "GET", "/users/me/username-history", token, nil)
+	history := decodeBody[[]UsernameChange](t, rec)
+	if len(history) != 1 || history[0].OldName != "alice" || history[0].NewName != "alice2" {
+		t.Errorf("history = %v", history)
+	}
+
+	// 冷却期内再次改名被拒
+	rec = doJSON(srv, "PUT", "/users/username", token, map[string]string{"username": "alice3"})
+	if rec.Code != 429 {
+		t.Fatalf("within cooldown: status = %d, want 429", rec.Code)
+	}
+
+	// 冷却期过后可以再改
+	srv.store.mu.Lock()
+	for _, c := range srv.store.renames {
+		c.ChangedAt = time.Now().Add(-31 * 24 * time.Hour)
+	}
+	srv.store.mu.Unlock()
+	rec = doJSON(srv, "PUT", "/users/username", token, map[string]string{"username": "alice3"})
+	if rec.Code != 200 {
+		t.Fatalf("after cooldown: status = %d", rec.Code)
+	}
+
+	// 旧用户名可以再次登录吗？不能，必须用新名字
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{"username": "alice", "password": "secret123"})
+	if rec.Code != 401 {
+		t.Errorf("old username login: status = %d, want 401", rec.Code)
+	}
+	rec = doJSON(srv, "POST", "/login", "", map[string]string{"username": "alice3", "password": "secret123"})
+	if rec.Code != 200 {
+		t.Errorf("new username login: status = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+// restrictedTaskFields 只有管理员能看到的任务字段（JSON 名）。
+var restrictedTaskFields = []string{"internal_note"}
+
+// taskView 角色感知的任务序列化：管理员看全量，
+// 普通用户的响应里直接去掉受限字段，而不是输出空值。
+func taskView(t *Task, viewer *User) map[string]any {
+	m := toJSONMap(t)
+	if viewer.IsAdmin {
+		return m
+	}
+	for _, f := range restrictedTaskFields {
+		delete(m, f)
+	}
+	return m
+}
+
+// taskViews 列表版本。
+func taskViews(ts []*Task, viewer *User) []map[string]any {
+	out := make([]map[string]any, len(ts))
+	for i, t := range ts {
+		out[i] = taskView(t, viewer)
+	}
+	return out
+}
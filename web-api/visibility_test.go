@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTaskFieldVisibilityByRole(t *testing.T) {
+	srv := newTestServer()
+	adminToken, _ := registerAndLogin(t, srv, "admin") // 第一个用户是管理员
+	userToken, _ := registerAndLogin(t, srv, "alice")
+
+	adminTask := makeTasks(t, srv, adminToken, "admin-task")[0]
+	userTask := makeTasks(t, srv, userToken, "user-task")[0]
+	srv.store.mu.Lock()
+	srv.store.tasks[adminTask.ID].InternalNote = "内部排期备注"
+	srv.store.tasks[userTask.ID].InternalNote = "内部排期备注"
+	srv.store.mu.Unlock()
+
+	// 管理员看到全部字段
+	rec := doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", adminTask.ID), adminToken, nil)
+	detail := decodeBody[map[string]any](t, rec)
+	if detail["internal_note"] != "内部排期备注" {
+		t.Errorf("admin view missing internal_note: %v", detail)
+	}
+
+	// 普通用户看不到受限字段
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", userTask.ID), userToken, nil)
+	detail = decodeBody[map[string]any](t, rec)
+	if _, ok := detail["internal_note"]; ok {
+		t.Errorf("user view leaks internal_note: %v", detail)
+	}
+	if detail["title"] != "user-task" {
+		t.Errorf("user view missing normal fields: %v", detail)
+	}
+
+	// 列表同样受限
+	rec = doJSON(srv, "GET", "/tasks", userToken, nil)
+	list := decodeBody[[]map[string]any](t, rec)
+	for _, item := range list {
+		if _, ok := item["internal_note"]; ok {
+			t.Errorf("list view leaks internal_note: %v", item)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWatchAndNotify(t *testing.T) {
+	srv := newTestServer()
+	ownerToken, _ := registerAndLogin(t, srv, "owner")
+	watcherToken, _ := registerAndLogin(t, srv, "watcher")
+
+	task := makeTasks(t, srv, ownerToken, "watched")[0]
+
+	rec := doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/watch", task.ID), watcherToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("watch: status = %d", rec.Code)
+	}
+	// 所有者也关注自己的任务
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/watch", task.ID), ownerToken, nil)
+
+	// 所有者更新任务
+	rec = doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), ownerToken,
+		map[string]string{"status": "doing"})
+	if rec.Code != 200 {
+		t.Fatalf("update: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	// 关注者收到通知
+	rec = doJSON(srv, "GET", "/notifications", watcherToken, nil)
+	if ns := decodeBody[[]Notification](t, rec); len(ns) != 1 || ns[0].TaskID != task.ID {
+		t.Errorf("watcher notifications = %v, want 1 for task", ns)
+	}
+	// 操作者自己不收通知
+	rec = doJSON(srv, "GET", "/notifications", ownerToken, nil)
+	if ns := decodeBody[[]Notification](t, rec); len(ns) != 0 {
+		t.Errorf("actor notifications = %v, want none", ns)
+	}
+}
+
+func TestUnwatchStopsNotifications(t *testing.T) {
+	srv := newTestServer()
+	ownerToken, _ := registerAndLogin(t, srv, "owner")
+	watcherToken, _ := registerAndLogin(t, srv, "watcher")
+	task := makeTasks(t, srv, ownerToken, "t")[0]
+
+	doJSON(srv, "POST", fmt.Sprintf("/tasks/%d/watch", task.ID), watcherToken, nil)
+	rec := doJSON(srv, "DELETE", fmt.Sprintf("/tasks/%d/watch", task.ID), watcherToken, nil)
+	if rec.Code != 200 {
+		t.Fatalf("unwatch: status = %d", rec.Code)
+	}
+
+	doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), ownerToken,
+		map[string]string{"title": "renamed"})
+	rec = doJSON(srv, "GET", "/notifications", watcherToken, nil)
+	if ns := decodeBody[[]Notification](t, rec); len(ns) != 0 {
+		t.Errorf("unwatched user got notifications: %v", ns)
+	}
+}
+
+func TestUpdateTaskRejectsInvalidValues(t *testing.T) {
+	srv := newTestServer()
+	token, _ := registerAndLogin(t, srv, "alice")
+	task := makeTasks(t, srv, token, "t")[0]
+
+	// PUT 的校验口径要和 PATCH / 批量接口一致
+	rec := doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token,
+		map[string]any{"status": "archive"})
+	if rec.Code != 400 {
+		t.Errorf("invalid status: status = %d, want 400", rec.Code)
+	}
+	rec = doJSON(srv, "PUT", fmt.Sprintf("/tasks/%d", task.ID), token,
+		map[string]any{"priority": 7})
+	if rec.Code != 400 {
+		t.Errorf("invalid priority: status = %d, want 400", rec.Code)
+	}
+	rec = doJSON(srv, "GET", fmt.Sprintf("/tasks/%d", task.ID), token, nil)
+	got := decodeBody[Task](t, rec)
+	if got.Status != "todo" || got.Priority != task.Priority {
+		t.Errorf("task changed by rejected update: %+v", got)
+	}
+}